@@ -6,9 +6,11 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -21,9 +23,15 @@ func main() {
 	yLen := flag.Int("y", 4, "height of board")
 	flag.StringVar(&grid, "grid", "", "populate grid with these characters (X*Y length), solve, and exit")
 	random := flag.Bool("rand", false, "populate grid with randomly generated characters, solve, and exit")
+	seed := flag.Int64("seed", 0, "seed for generating random grids, to make them reproducible (default uses current time)")
 	quiet := flag.Bool("q", false, "do not display grid in output")
 	veryQuiet := flag.Bool("qq", false, "do not display grid or solutions in output")
 	words := flag.String("words", "", "optional file containing valid words separated by newline")
+	cols := flag.Int("cols", 0, "number of columns for solutions (default fits terminal width)")
+	width := flag.Int("width", 0, "width of each column of solutions (default fits longest word)")
+	sep := flag.String("sep", "", "separator between columns of solutions (default two spaces)")
+	alignRight := flag.Bool("right", false, "align solutions to the right of their columns")
+	tiers := flag.Bool("tiers", false, "show the number of solutions scoring each number of points")
 	flag.Parse()
 
 	var quietLevel int
@@ -33,11 +41,26 @@ func main() {
 		quietLevel = 1
 	}
 
+	// Any seed given, including 0, makes random grids reproducible.
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			rnd = rand.New(rand.NewSource(*seed))
+		}
+	})
+
 	if *words != "" && quietLevel == 0 {
 		fmt.Println("loading words from", *words)
 	}
 
-	err := runBoard(grid, *words, *xLen, *yLen, quietLevel, *random)
+	format := solver.ColumnFormat{
+		Columns:    *cols,
+		Width:      *width,
+		LineWidth:  terminalWidth(),
+		Separator:  *sep,
+		AlignRight: *alignRight,
+	}
+
+	err := runBoard(grid, *words, *xLen, *yLen, quietLevel, *random, *tiers, format)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
@@ -45,7 +68,7 @@ func main() {
 }
 
 // runBoard loops getting grid data and finding solutions for that grid.
-func runBoard(grid, wordsFile string, xlen, ylen, quietLevel int, random bool) error {
+func runBoard(grid, wordsFile string, xlen, ylen, quietLevel int, random, tiers bool, format solver.ColumnFormat) error {
 	sol, err := solver.New(xlen, ylen, wordsFile)
 	if err != nil {
 		return err
@@ -55,9 +78,12 @@ func runBoard(grid, wordsFile string, xlen, ylen, quietLevel int, random bool) e
 	}
 	ever := true
 	boardSize := sol.BoardSize()
+	var last string
+	var lastWords []string
 	for ever {
+		var edited bool
 		if grid == "" {
-			grid, err = readGridFromUser(boardSize)
+			grid, edited, err = readGridFromUser(boardSize, last)
 			if err != nil {
 				return err
 			}
@@ -68,27 +94,46 @@ func runBoard(grid, wordsFile string, xlen, ylen, quietLevel int, random bool) e
 			ever = false
 		}
 
-		start := time.Now()
-		words, err := sol.Solve(grid)
+		words, err := solveGrid(os.Stdout, sol, grid, lastWords, edited, quietLevel, tiers, format)
 		if err != nil {
 			return err
 		}
-		elapsed := time.Since(start)
+		last = grid
+		lastWords = words
+		grid = ""
+	}
+	return nil
+}
 
-		if len(words) == 0 {
-			continue
-		}
+// solveGrid finds the solutions for one grid and writes them to w, showing
+// the words added and removed since lastWords if the grid was edited. The
+// solutions are returned so they can be compared with the next grid.
+func solveGrid(w io.Writer, sol solver.Solver, grid string, lastWords []string, edited bool, quietLevel int, tiers bool, format solver.ColumnFormat) ([]string, error) {
+	start := time.Now()
+	words, err := sol.Solve(grid)
+	if err != nil {
+		return nil, err
+	}
+	elapsed := time.Since(start)
+	if edited {
+		showDiff(w, lastWords, words)
+	}
+	if len(words) == 0 {
+		return words, nil
+	}
 
-		fmt.Printf("Found %d solutions for %dx%d grid in %s\n", len(words), xlen, ylen, elapsed)
-		if quietLevel < 2 {
-			if quietLevel < 1 {
-				fmt.Print(sol.Grid(grid))
-			}
-			showWords(words)
+	xlen, ylen := sol.Dimensions()
+	fmt.Fprintf(w, "Found %d solutions for %dx%d grid in %s\n", len(words), xlen, ylen, elapsed)
+	if tiers {
+		fmt.Fprintln(w, solver.FormatScoreTiers(sol.ScoreTiers(words)))
+	}
+	if quietLevel < 2 {
+		if quietLevel < 1 {
+			fmt.Fprint(w, sol.Grid(grid))
 		}
-		grid = ""
+		showWords(w, words, format)
 	}
-	return nil
+	return words, nil
 }
 
 var rnd *rand.Rand
@@ -107,36 +152,75 @@ func randomGrid(size int) string {
 	return string(grid)
 }
 
-// showWords prints words in four columns.
-func showWords(words []string) {
-	// Sort words by lenght.
-	sort.Slice(words, func(i, j int) bool { return len(words[i]) > len(words[j]) })
-	for i, w := range words {
-		if i%4 == 0 {
-			fmt.Println("")
-		}
-		fmt.Printf("%-18s", w)
+// showWords prints words in columns.
+func showWords(w io.Writer, words []string, format solver.ColumnFormat) {
+	// Sort words by lenght, keeping words of the same length in alphabetical
+	// order so that output is stable.
+	sort.SliceStable(words, func(i, j int) bool { return len(words[i]) > len(words[j]) })
+	fmt.Fprintln(w, "")
+	fmt.Fprint(w, solver.FormatWords(words, format))
+}
+
+// showDiff prints the words added and removed by editing a grid.
+func showDiff(w io.Writer, before, after []string) {
+	removed, added, _ := solver.DiffWords(before, after)
+	if len(added) == 0 && len(removed) == 0 {
+		fmt.Fprintln(w, "No change in solutions")
+		return
+	}
+	if len(added) != 0 {
+		fmt.Fprintf(w, "Added %d: %s\n", len(added), strings.Join(added, " "))
+	}
+	if len(removed) != 0 {
+		fmt.Fprintf(w, "Removed %d: %s\n", len(removed), strings.Join(removed, " "))
 	}
-	fmt.Println("")
 }
 
-// readGridFromUser reads input from user, rejecting invalid characters.
-func readGridFromUser(boardSize int) (string, error) {
-	consReader := bufio.NewReader(os.Stdin)
-	fmt.Printf("\nEnter %d letters into boggle grid or * for random: ", boardSize)
+// terminalWidth returns the width of the terminal given by the COLUMNS
+// environment variable, or zero if not known.
+func terminalWidth() int {
+	n, err := strconv.Atoi(os.Getenv("COLUMNS"))
+	if err != nil || n < 1 {
+		return 0
+	}
+	return n
+}
+
+// consReader reads user input. It is shared by all prompts so that input
+// buffered by one prompt is not lost to the next.
+var consReader = bufio.NewReader(os.Stdin)
+
+// readGridFromUser reads input from user, rejecting invalid characters. If
+// there is a last grid, then an edit command changes one square of it, and
+// true is returned to indicate that the grid is an edit of the last grid.
+func readGridFromUser(boardSize int, last string) (string, bool, error) {
+	if last != "" {
+		fmt.Printf("\nEnter %d letters into boggle grid, * for random, or set <square> <letter> to edit last grid: ", boardSize)
+	} else {
+		fmt.Printf("\nEnter %d letters into boggle grid or * for random: ", boardSize)
+	}
 	var grid string
 	var valid bool
 	for {
 		input, err := consReader.ReadString('\n')
 		if err != nil {
-			return "", errors.New("error reading input")
+			return "", false, errors.New("error reading input")
 		}
 		input = strings.TrimRight(input, "\n")
 		if len(input) == 0 {
-			return "", nil
+			return "", false, nil
 		}
 		if len(input) == 1 && strings.HasPrefix(input, "*") {
-			return randomGrid(boardSize), nil
+			return randomGrid(boardSize), false, nil
+		}
+		if isEdit(input) && grid == "" {
+			edited, err := editGrid(last, input)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				fmt.Print("\nEnter edit or letters: ")
+				continue
+			}
+			return edited, true, nil
 		}
 		input = strings.ToLower(input)
 		valid = true
@@ -160,5 +244,48 @@ func readGridFromUser(boardSize int) (string, error) {
 		grid = grid[:boardSize]
 	}
 
-	return grid, nil
+	return grid, false, nil
+}
+
+// isEdit returns true if the input is an edit command.
+func isEdit(input string) bool {
+	return strings.HasPrefix(input, "=") || strings.HasPrefix(strings.ToLower(input), "set ")
+}
+
+// editGrid returns the grid with one square changed as given by an edit
+// command. The command is "set <square> <letter>", such as "set 3 x" to set
+// square 3 to x, or the short form "=<square><letter>", such as "=3x". The Qu
+// tile is given as "q" or "qu".
+func editGrid(grid, edit string) (string, error) {
+	if grid == "" {
+		return "", errors.New("no previous grid to edit")
+	}
+	edit = strings.ToLower(edit)
+	if short, ok := strings.CutPrefix(edit, "="); ok {
+		i := strings.IndexFunc(short, func(r rune) bool { return r < '0' || r > '9' })
+		if i <= 0 {
+			return "", fmt.Errorf("invalid edit %q: expected =<square><letter>", edit)
+		}
+		return setSquare(grid, short[:i], short[i:])
+	}
+	fields := strings.Fields(edit)
+	if len(fields) != 3 || fields[0] != "set" {
+		return "", fmt.Errorf("invalid edit %q: expected set <square> <letter>", edit)
+	}
+	return setSquare(grid, fields[1], fields[2])
+}
+
+// setSquare returns the grid with the given square set to the given letter.
+func setSquare(grid, square, letter string) (string, error) {
+	sq, err := strconv.Atoi(square)
+	if err != nil || sq < 0 || sq >= len(grid) {
+		return "", fmt.Errorf("invalid square %q: must be 0 to %d", square, len(grid)-1)
+	}
+	if letter == "qu" {
+		letter = "q"
+	}
+	if len(letter) != 1 || letter[0] < 'a' || letter[0] > 'z' {
+		return "", fmt.Errorf("invalid letter %q: expected a single letter or qu", letter)
+	}
+	return grid[:sq] + letter + grid[sq+1:], nil
 }
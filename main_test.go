@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gammazero/bogglesolver/solver"
+)
+
+func TestSolveGrid(t *testing.T) {
+	wordsPath := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(wordsPath, []byte("cat\ntea\nquit\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sol, err := solver.New(3, 3, wordsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The default output shows the grid and the solutions.
+	var out bytes.Buffer
+	words, err := solveGrid(&out, sol, "catxeaqit", nil, false, 0, false, solver.ColumnFormat{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Words are shown, and returned, ordered by length.
+	if !reflect.DeepEqual(words, []string{"quit", "cat", "tea"}) {
+		t.Fatal("wrong solutions:", words)
+	}
+	text := out.String()
+	if !strings.HasPrefix(text, "Found 3 solutions for 3x3 grid in ") {
+		t.Error("missing summary:\n" + text)
+	}
+	if !strings.Contains(text, sol.Grid("catxeaqit")) {
+		t.Error("missing grid:\n" + text)
+	}
+	for _, w := range words {
+		if !strings.Contains(text, w) {
+			t.Errorf("missing word %q:\n%s", w, text)
+		}
+	}
+
+	// Quiet output omits the grid, and very quiet output omits the words.
+	out.Reset()
+	if _, err = solveGrid(&out, sol, "catxeaqit", nil, false, 1, false, solver.ColumnFormat{}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), "+---+") || !strings.Contains(out.String(), "quit") {
+		t.Error("wrong quiet output:\n" + out.String())
+	}
+	out.Reset()
+	if _, err = solveGrid(&out, sol, "catxeaqit", nil, false, 2, false, solver.ColumnFormat{}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), "quit") {
+		t.Error("wrong very quiet output:\n" + out.String())
+	}
+
+	// An edited grid shows the change in solutions.
+	out.Reset()
+	if _, err = solveGrid(&out, sol, "catxeaxit", words, true, 2, false, solver.ColumnFormat{}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out.String(), "Removed 1: quit\n") {
+		t.Error("wrong edit output:\n" + out.String())
+	}
+}
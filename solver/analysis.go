@@ -0,0 +1,298 @@
+package solver
+
+import (
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+// DistinctLetters returns the number of distinct letters in the grid. A grid
+// with more variety usually contains more words.
+//
+// The Qu tile, given as 'q', counts as both of its letters, so the grid "quit"
+// has four distinct letters, as does the grid "qit". Letters are counted
+// without regard to case, and characters that are not letters are ignored.
+func DistinctLetters(grid string) int {
+	letters := letterBits(grid)
+	if letters&(1<<('q'-'a')) != 0 {
+		letters |= 1 << ('u' - 'a')
+	}
+	return bits.OnesCount32(letters)
+}
+
+// SolveByDistinctLetters solves the grid and groups the words found by the
+// number of distinct letters in each word. This finds the most repetitive or
+// most diverse words on the board.
+//
+// Words are counted as spelled, so a word using the Qu tile has both q and u,
+// and "queue" has four distinct letters.
+func (s Solver) SolveByDistinctLetters(grid string) (map[int][]string, error) {
+	words, err := s.Solve(grid)
+	if err != nil {
+		return nil, err
+	}
+	byCount := make(map[int][]string)
+	for _, w := range words {
+		n := bits.OnesCount32(letterBits(w))
+		byCount[n] = append(byCount[n], w)
+	}
+	return byCount, nil
+}
+
+// SolveByFirstLetter solves the grid and groups the words found by the first
+// letter of each word. This shows which letters begin the most words. The
+// letter keys are lower case, regardless of the WordCase of the Solver. Words
+// are grouped as spelled, so words using the Qu tile, such as "quit", are
+// grouped under 'q'. The words in each group are in the order returned by
+// Solve.
+func (s Solver) SolveByFirstLetter(grid string) (map[byte][]string, error) {
+	words, err := s.Solve(grid)
+	if err != nil {
+		return nil, err
+	}
+	byLetter := make(map[byte][]string)
+	for _, w := range words {
+		if w == "" {
+			continue // emptied by a WithWordFilter function
+		}
+		c := w[0] | 0x20 // lower case
+		byLetter[c] = append(byLetter[c], w)
+	}
+	return byLetter, nil
+}
+
+// MaxShortFraction is the largest fraction of a board's words that may be of
+// the minimum word length before LengthQuality reports the board as
+// monotonous.
+const MaxShortFraction = 0.5
+
+// LengthQuality describes the lengths of the words found on a board.
+type LengthQuality struct {
+	// Counts maps each word length to the number of words of that length.
+	Counts map[int]int
+	// Words is the total number of words.
+	Words int
+	// ShortFraction is the fraction of the words that are of the minimum word
+	// length, or zero if there are no words.
+	ShortFraction float64
+	// Monotonous is true if ShortFraction is more than MaxShortFraction.
+	Monotonous bool
+}
+
+// LengthQuality solves the grid and counts the words found of each length. A
+// board with only a few common letters, such as many e's and t's, often has
+// many words of the minimum word length and few longer words, which makes a
+// dull board despite having many words. Such a board is reported as
+// Monotonous, which allows board generators to reject it.
+//
+// The length of a word is the number of letters in the word, so a word using
+// the Qu tile counts both letters of the tile.
+func (s Solver) LengthQuality(grid string) (LengthQuality, error) {
+	words, err := s.Solve(grid)
+	if err != nil {
+		return LengthQuality{}, err
+	}
+	q := LengthQuality{
+		Counts: make(map[int]int),
+		Words:  len(words),
+	}
+	for _, w := range words {
+		q.Counts[len(w)]++
+	}
+	if q.Words != 0 {
+		q.ShortFraction = float64(q.Counts[s.MinWordLength()]) / float64(q.Words)
+	}
+	q.Monotonous = q.ShortFraction > MaxShortFraction
+	return q, nil
+}
+
+// BoardQuality summarizes the words found on a board, for ranking boards.
+type BoardQuality struct {
+	// Words is the number of distinct words found.
+	Words int
+	// Score is the total points scored for all the words found.
+	Score int
+	// Longest is the length of the longest word found, or zero if there are
+	// no words.
+	Longest int
+	// DistinctLetters is the number of distinct letters on the board, as
+	// returned by DistinctLetters.
+	DistinctLetters int
+	// Density is the points scored per square of the board: Score divided by
+	// the number of squares with letters. A higher density is a richer board,
+	// and boards of different sizes can be compared by density.
+	Density float64
+}
+
+// BoardQuality solves the grid once and returns the measures of the words
+// found that board generators use to rank candidate boards. Words are scored
+// using Score, unless the Solver was created using WithScoreFunc, and the
+// length of a word is the number of letters in the word, so a word using the
+// Qu tile counts both letters of the tile. Squares that are absent from a
+// masked board or are padding are not counted.
+func (s Solver) BoardQuality(grid string) (BoardQuality, error) {
+	board, err := s.board(grid)
+	if err != nil {
+		return BoardQuality{}, err
+	}
+	words, err := s.solveBoard(board, &Scratch{}, nil)
+	if err != nil {
+		return BoardQuality{}, err
+	}
+	var squares int
+	letters := []byte(board)
+	for sq, c := range letters {
+		if (s.mask != nil && !s.mask[sq]) || !isLetter(c) {
+			letters[sq] = ' '
+			continue
+		}
+		squares++
+	}
+	q := BoardQuality{
+		Words:           len(words),
+		DistinctLetters: DistinctLetters(string(letters)),
+	}
+	for _, w := range words {
+		q.Score += s.scoreWord(w, len(normalizeWord(w, s.cfg.caseSensitive)))
+		q.Longest = max(q.Longest, len(w))
+	}
+	if squares != 0 {
+		q.Density = float64(q.Score) / float64(squares)
+	}
+	return q, nil
+}
+
+// WordPair is a pair of words found on a board, where the longer word begins
+// with the shorter word.
+type WordPair struct {
+	Shorter string
+	Longer  string
+}
+
+// PrefixPairs solves the grid and returns every pair of words found where the
+// longer word begins with the shorter word, such as "cat" and "cats". Both
+// words of each pair are found on the board, which makes the pairs useful as
+// hints for extending a word that a player has already found. The pairs are
+// sorted by the shorter word, and then by the longer word.
+func (s Solver) PrefixPairs(grid string) ([]WordPair, error) {
+	extendable, err := s.ExtendableWords(grid)
+	if err != nil {
+		return nil, err
+	}
+	var pairs []WordPair
+	for shorter, longer := range extendable {
+		for _, w := range longer {
+			pairs = append(pairs, WordPair{Shorter: shorter, Longer: w})
+		}
+	}
+	slices.SortFunc(pairs, func(a, b WordPair) int {
+		if c := strings.Compare(a.Shorter, b.Shorter); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Longer, b.Longer)
+	})
+	return pairs, nil
+}
+
+// letterBits returns a bitmask with a bit set for each letter in s, without
+// regard to case.
+func letterBits(s string) uint32 {
+	var letters uint32
+	for i := 0; i < len(s); i++ {
+		c := s[i] | 0x20 // lower case
+		if c >= 'a' && c <= 'z' {
+			letters |= 1 << (c - 'a')
+		}
+	}
+	return letters
+}
+
+// LetterStats describes the layout of vowels and consonants on a board.
+type LetterStats struct {
+	// Vowels is the number of vowels on the board.
+	Vowels int
+	// Consonants is the number of consonants on the board.
+	Consonants int
+	// ConsonantRows lists the rows, numbered from 0, that have no vowels.
+	ConsonantRows []int
+	// ConsonantCols lists the columns, numbered from 0, that have no vowels.
+	ConsonantCols []int
+}
+
+// LetterStats counts the vowels and consonants in the grid, and finds the rows
+// and columns that have no vowels. Such rows and columns are often regions
+// where few words can be formed, which makes these stats a cheap way to reject
+// poor boards without solving them.
+//
+// The vowels are a, e, i, o, and u, and y is a consonant. The Qu tile counts
+// as both a consonant and a vowel, so a row with the Qu tile has a vowel.
+// Squares absent from a masked board are not counted, and a row or column
+// with no squares present is not listed.
+func (s Solver) LetterStats(grid string) (LetterStats, error) {
+	board, err := s.board(grid)
+	if err != nil {
+		return LetterStats{}, err
+	}
+
+	var stats LetterStats
+	rowVowels := make([]int, s.rows)
+	colVowels := make([]int, s.cols)
+	rowPresent := make([]bool, s.rows)
+	colPresent := make([]bool, s.cols)
+	for sq := 0; sq < len(board); sq++ {
+		if s.mask != nil && !s.mask[sq] {
+			continue
+		}
+		row, col := sq/s.cols, sq%s.cols
+		rowPresent[row] = true
+		colPresent[col] = true
+		switch board[sq] | 0x20 {
+		case 'a', 'e', 'i', 'o', 'u':
+			stats.Vowels++
+		case 'q':
+			stats.Vowels++
+			stats.Consonants++
+		default:
+			stats.Consonants++
+			continue
+		}
+		rowVowels[row]++
+		colVowels[col]++
+	}
+	for row, n := range rowVowels {
+		if n == 0 && rowPresent[row] {
+			stats.ConsonantRows = append(stats.ConsonantRows, row)
+		}
+	}
+	for col, n := range colVowels {
+		if n == 0 && colPresent[col] {
+			stats.ConsonantCols = append(stats.ConsonantCols, col)
+		}
+	}
+	return stats, nil
+}
+
+// ExtendableWords solves the grid and maps each word found to the longer words
+// found that begin with it, such as "cat" to "cats" and "catty". This gives
+// hints for extending words. Words are compared as spelled, so "quit" is
+// extended by "quite". Only words that have extensions are in the map, and the
+// extensions of each word are sorted.
+func (s Solver) ExtendableWords(grid string) (map[string][]string, error) {
+	words, err := s.Solve(grid)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(words)
+
+	// In sorted order, the words that begin with a word follow it directly.
+	extendable := make(map[string][]string)
+	for i, w := range words {
+		for _, longer := range words[i+1:] {
+			if !strings.HasPrefix(longer, w) {
+				break
+			}
+			extendable[w] = append(extendable[w], longer)
+		}
+	}
+	return extendable, nil
+}
@@ -0,0 +1,311 @@
+package solver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDistinctLetters(t *testing.T) {
+	tests := []struct {
+		grid   string
+		expect int
+	}{
+		{"", 0},
+		{"aaaa", 1},
+		{"abcdefghijklmnopqrstuvwxyz", 26},
+		{"qit", 4},
+		{"quit", 4},
+		{"QADFETRIIHKRIFLV", 13},
+	}
+	for _, tc := range tests {
+		if n := DistinctLetters(tc.grid); n != tc.expect {
+			t.Errorf("expected %d distinct letters in %q, got %d", tc.expect, tc.grid, n)
+		}
+	}
+}
+
+func TestSolveByDistinctLetters(t *testing.T) {
+	wordsPath := writeWords(t, "queen", "queue", "quit", "tee", "teen", "net")
+	s, err := New(4, 2, wordsPath, WithWordCase(UpperCase))
+	if err != nil {
+		t.Fatal(err)
+	}
+	byCount, err := s.SolveByDistinctLetters("qeuetnex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := map[int][]string{
+		2: {"TEE"},
+		3: {"NET", "QUEUE", "TEEN"},
+		4: {"QUEEN"},
+	}
+	if !reflect.DeepEqual(byCount, expect) {
+		t.Fatalf("expected %v, got %v", expect, byCount)
+	}
+}
+
+func TestSolveByFirstLetter(t *testing.T) {
+	wordsPath := writeWords(t, "cat", "cats", "act", "quit", "quite", "tea", "sty")
+	s, err := New(3, 3, wordsPath, WithWordCase(TitleCase))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// +---+---+---+
+	// | C | A | T |
+	// +---+---+---+
+	// | S | T | Y |
+	// +---+---+---+
+	// | Qu| I | E |
+	// +---+---+---+
+	byLetter, err := s.SolveByFirstLetter("catstyqie")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := map[byte][]string{
+		'a': {"Act"},
+		'c': {"Cat", "Cats"},
+		'q': {"Quit", "Quite"},
+		's': {"Sty"},
+	}
+	if !reflect.DeepEqual(byLetter, expect) {
+		t.Fatal("wrong words by first letter:", byLetter)
+	}
+
+	// A word filter that returns an empty word does not cause a panic.
+	s, err = New(3, 3, wordsPath, WithWordFilter(func(word string) (string, bool) {
+		if word == "sty" {
+			return "", true
+		}
+		return word, true
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	byLetter, err = s.SolveByFirstLetter("catstyqie")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := byLetter['s']; ok || len(byLetter) != 3 {
+		t.Fatal("wrong words by first letter:", byLetter)
+	}
+}
+
+func TestPrefixPairs(t *testing.T) {
+	wordsPath := writeWords(t, "cat", "cats", "catty", "act", "acts", "quit", "quite", "tea")
+	s, err := New(3, 3, wordsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// +---+---+---+
+	// | C | A | T |
+	// +---+---+---+
+	// | S | T | Y |
+	// +---+---+---+
+	// | Qu| I | E |
+	// +---+---+---+
+	pairs, err := s.PrefixPairs("catstyqie")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []WordPair{
+		{"act", "acts"},
+		{"cat", "cats"},
+		{"cat", "catty"},
+		{"quit", "quite"},
+	}
+	if !reflect.DeepEqual(pairs, expect) {
+		t.Fatalf("expected %v, got %v", expect, pairs)
+	}
+
+	pairs, err = s.PrefixPairs("xxxxxxxxx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pairs) != 0 {
+		t.Fatal("expected no pairs, got", pairs)
+	}
+}
+
+func TestLengthQuality(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	varied, err := s.LengthQuality("qadfetriihkriflvctor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := LengthQuality{
+		Counts:        map[int]int{3: 38, 4: 29, 5: 12, 6: 1},
+		Words:         80,
+		ShortFraction: 38.0 / 80.0,
+	}
+	if !reflect.DeepEqual(varied, expect) {
+		t.Fatalf("expected %+v, got %+v", expect, varied)
+	}
+
+	monotonous, err := s.LengthQuality("eteatetatetetatetete")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect = LengthQuality{
+		Counts:        map[int]int{3: 9, 4: 2},
+		Words:         11,
+		ShortFraction: 9.0 / 11.0,
+		Monotonous:    true,
+	}
+	if !reflect.DeepEqual(monotonous, expect) {
+		t.Fatalf("expected %+v, got %+v", expect, monotonous)
+	}
+
+	empty, err := s.LengthQuality("xxxxxxxxxxxxxxxxxxxx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if empty.Words != 0 || empty.ShortFraction != 0 || empty.Monotonous {
+		t.Fatalf("wrong quality for board with no words: %+v", empty)
+	}
+}
+
+func TestBoardQuality(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rich, err := s.BoardQuality("qadfetriihkriflvctor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := BoardQuality{
+		Words:           80,
+		Score:           38 + 29 + 12*2 + 3,
+		Longest:         6,
+		DistinctLetters: 15,
+		Density:         94.0 / 20.0,
+	}
+	if rich != expect {
+		t.Fatalf("expected %+v, got %+v", expect, rich)
+	}
+
+	sparse, err := s.BoardQuality("eteatetatetetatetete")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect = BoardQuality{
+		Words:           11,
+		Score:           11,
+		Longest:         4,
+		DistinctLetters: 3,
+		Density:         11.0 / 20.0,
+	}
+	if sparse != expect {
+		t.Fatalf("expected %+v, got %+v", expect, sparse)
+	}
+	if sparse.Density >= rich.Density {
+		t.Fatal("expected rich board to have greater density")
+	}
+
+	empty, err := s.BoardQuality("xxxxxxxxxxxxxxxxxxxx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if empty != (BoardQuality{DistinctLetters: 1}) {
+		t.Fatalf("wrong quality for board with no words: %+v", empty)
+	}
+
+	// Only squares with letters are counted.
+	s, err = New(3, 2, writeWords(t, "cat"), WithPadding(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := s.BoardQuality("cat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Density != 1.0/3.0 || q.DistinctLetters != 3 {
+		t.Fatalf("wrong quality for padded board: %+v", q)
+	}
+}
+
+func TestLetterStats(t *testing.T) {
+	s, err := New(4, 4, writeWords(t, "cat"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// +---+---+---+---+
+	// | S | T | R | Y |
+	// +---+---+---+---+
+	// | A | B | C | D |
+	// +---+---+---+---+
+	// | E | F | G | H |
+	// +---+---+---+---+
+	// | Qu| J | K | L |
+	// +---+---+---+---+
+	stats, err := s.LetterStats("STRYABCDEFGHQJKL")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := LetterStats{
+		Vowels:        3,
+		Consonants:    14,
+		ConsonantRows: []int{0},
+		ConsonantCols: []int{1, 2, 3},
+	}
+	if !reflect.DeepEqual(stats, expect) {
+		t.Fatalf("expected %+v, got %+v", expect, stats)
+	}
+
+	// Absent squares are not counted, and the empty column is not listed.
+	mask := []bool{
+		true, true, false,
+		true, true, false,
+	}
+	s, err = NewMasked(3, 2, mask, writeWords(t, "cat"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats, err = s.LetterStats("ab.cd.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect = LetterStats{
+		Vowels:        1,
+		Consonants:    3,
+		ConsonantRows: []int{1},
+		ConsonantCols: []int{1},
+	}
+	if !reflect.DeepEqual(stats, expect) {
+		t.Fatalf("expected %+v, got %+v", expect, stats)
+	}
+
+	if _, err = s.LetterStats("ab.c1."); !errors.Is(err, ErrInvalidCharacter) {
+		t.Fatal("expected ErrInvalidCharacter, got", err)
+	}
+}
+
+func TestExtendableWords(t *testing.T) {
+	wordsPath := writeWords(t, "cat", "cats", "catty", "act", "quit", "quite", "quiet", "tea")
+	s, err := New(3, 3, wordsPath, WithSortResults(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// +---+---+---+
+	// | C | A | T |
+	// +---+---+---+
+	// | S | T | Y |
+	// +---+---+---+
+	// | Qu| I | E |
+	// +---+---+---+
+	extendable, err := s.ExtendableWords("catstyqie")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := map[string][]string{
+		"cat":  {"cats", "catty"},
+		"quit": {"quite"},
+	}
+	if !reflect.DeepEqual(extendable, expect) {
+		t.Fatal("wrong extendable words:", extendable)
+	}
+}
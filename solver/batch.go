@@ -0,0 +1,37 @@
+package solver
+
+import (
+	"fmt"
+	"sort"
+)
+
+// BoardResult holds a grid and the number of solutions found in it.
+type BoardResult struct {
+	Grid  string
+	Count int
+}
+
+// TopBoards solves each of the grids and returns the n grids with the most
+// solutions, sorted by descending number of solutions. Grids with the same
+// number of solutions are kept in the order given. If there are fewer than n
+// grids, then all are returned.
+func (s Solver) TopBoards(grids []string, n int) ([]BoardResult, error) {
+	results := make([]BoardResult, len(grids))
+	for i, grid := range grids {
+		count, err := s.SolveCount(grid)
+		if err != nil {
+			return nil, fmt.Errorf("grid %d: %w", i, err)
+		}
+		results[i] = BoardResult{
+			Grid:  grid,
+			Count: count,
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Count > results[j].Count
+	})
+	if n < len(results) {
+		results = results[:max(n, 0)]
+	}
+	return results, nil
+}
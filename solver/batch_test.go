@@ -0,0 +1,45 @@
+package solver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTopBoards(t *testing.T) {
+	s, err := New(4, 4, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grids := []string{
+		"xxxxxxxxxxxxxxxx",
+		"qadfetriihkriflv",
+		"qazwsxedcrfvtgby",
+		"zzzzzzzzzzzzzzzz",
+	}
+	top, err := s.TopBoards(grids, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []BoardResult{
+		{"qadfetriihkriflv", 62},
+		{"qazwsxedcrfvtgby", 33},
+		{"xxxxxxxxxxxxxxxx", 0},
+	}
+	if !reflect.DeepEqual(top, expect) {
+		t.Fatal("wrong top boards:", top)
+	}
+
+	top, err = s.TopBoards(grids, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != len(grids) {
+		t.Fatal("expected all boards")
+	}
+
+	_, err = s.TopBoards([]string{"qadfetriihkriflv", "abc"}, 1)
+	if !errors.Is(err, ErrGridTooShort) {
+		t.Fatal("expected ErrGridTooShort, got", err)
+	}
+}
@@ -0,0 +1,209 @@
+package solver
+
+import (
+	"math"
+	"slices"
+
+	"github.com/gammazero/radixtree"
+)
+
+// DefaultFalsePositiveRate is the false positive rate of the BloomFilter
+// backend, unless set otherwise by WithFalsePositiveRate.
+const DefaultFalsePositiveRate = 0.001
+
+// bloomDict is a dictionary held in a bloom filter. The filter holds every
+// prefix of every key, so that a search is pruned when it steps to a prefix
+// that no key begins with, and holds each key followed by wordEnd to mark
+// where words end. Words are not stored, and are recovered from their keys.
+type bloomDict struct {
+	bits []uint64
+	// m is the number of bits and k the number of hashes of each entry.
+	m, k uint64
+	// count is the number of words.
+	count int
+	// prefixes is the number of distinct prefixes of the keys.
+	prefixes int
+	// maxLen is the length of the longest key.
+	maxLen int
+	// caseSensitive is true if keys are not all lower case.
+	caseSensitive bool
+	// reversed is true if the letters of each key are in reverse order.
+	reversed bool
+}
+
+// wordEnd follows a key in the filter to mark the end of a word.
+const wordEnd = 0
+
+// newBloomDict creates a bloomDict holding the keys of the tree, with the
+// given false positive rate for each lookup.
+func newBloomDict(tree *radixtree.Tree, rate float64, caseSensitive, reversed bool) *bloomDict {
+	if rate <= 0 || rate >= 1 {
+		rate = DefaultFalsePositiveRate
+	}
+	keys := make([]string, 0, tree.Len())
+	tree.Walk("", func(key string, _ any) bool {
+		keys = append(keys, key)
+		return false
+	})
+	slices.Sort(keys)
+
+	// Count the distinct prefixes. In sorted order, each key adds the
+	// prefixes that are longer than its common prefix with the key before it.
+	var prefixes, maxLen int
+	for i, key := range keys {
+		var n int
+		if i != 0 {
+			prev := keys[i-1]
+			for n < len(prev) && n < len(key) && prev[n] == key[n] {
+				n++
+			}
+		}
+		prefixes += len(key) - n
+		maxLen = max(maxLen, len(key))
+	}
+
+	// Size the filter for the number of entries and the false positive rate.
+	entries := float64(max(1, prefixes+len(keys)))
+	m := uint64(math.Ceil(-entries * math.Log(rate) / (math.Ln2 * math.Ln2)))
+	m = max(64, m)
+	k := uint64(max(1, math.Round(float64(m)/entries*math.Ln2)))
+	d := &bloomDict{
+		bits:          make([]uint64, (m+63)/64),
+		m:             m,
+		k:             k,
+		count:         len(keys),
+		prefixes:      prefixes,
+		maxLen:        maxLen,
+		caseSensitive: caseSensitive,
+		reversed:      reversed,
+	}
+	buf := make([]byte, 0, maxLen+1)
+	for _, key := range keys {
+		buf = append(buf[:0], key...)
+		for i := 1; i <= len(buf); i++ {
+			d.add(buf[:i])
+		}
+		d.add(append(buf, wordEnd))
+	}
+	return d
+}
+
+// hash returns two hashes of the entry, using 64-bit FNV-1a split in two.
+func (d *bloomDict) hash(entry []byte) (uint64, uint64) {
+	h := uint64(14695981039346656037)
+	for _, c := range entry {
+		h ^= uint64(c)
+		h *= 1099511628211
+	}
+	return h & 0xffffffff, h>>32 | 1
+}
+
+func (d *bloomDict) add(entry []byte) {
+	h1, h2 := d.hash(entry)
+	for i := uint64(0); i < d.k; i++ {
+		bit := (h1 + i*h2) % d.m
+		d.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+func (d *bloomDict) has(entry []byte) bool {
+	h1, h2 := d.hash(entry)
+	for i := uint64(0); i < d.k; i++ {
+		bit := (h1 + i*h2) % d.m
+		if d.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// keyWord returns the word for a key, putting back the u of a leading Qu
+// tile.
+func (d *bloomDict) keyWord(key []byte) string {
+	word := slices.Clone(key)
+	if d.reversed {
+		slices.Reverse(word)
+	}
+	if len(word) != 0 && (word[0] == 'q' || (d.caseSensitive && word[0] == 'Q')) {
+		word = slices.Insert(word, 1, 'u')
+	}
+	return string(word)
+}
+
+func (d *bloomDict) Len() int {
+	return d.count
+}
+
+// memory counts as nodes the prefixes held in the filter, plus the root, which
+// are the nodes that a trie of the same keys would have.
+func (d *bloomDict) memory() MemoryEstimate {
+	return MemoryEstimate{
+		Nodes: d.prefixes + 1,
+		Bytes: len(d.bits) * 8,
+	}
+}
+
+// walk finds the keys that begin with the prefix by stepping through every
+// letter that continues a prefix in the filter, so it may call fn with false
+// positives.
+func (d *bloomDict) walk(prefix string, fn func(key, word string)) {
+	st := d.stepper().(*bloomStepper)
+	for i := 0; i < len(prefix); i++ {
+		if !st.next(prefix[i]) {
+			return
+		}
+	}
+	var visit func()
+	visit = func() {
+		if word, ok := st.word(); ok {
+			fn(string(st.key), word)
+		}
+		if len(st.key) == d.maxLen {
+			return
+		}
+		for c := byte('A'); c <= 'z'; c++ {
+			if !isLetter(c) || (c < 'a' && !d.caseSensitive) {
+				continue
+			}
+			if st.next(c) {
+				visit()
+				st.key = st.key[:len(st.key)-1]
+			}
+		}
+	}
+	visit()
+}
+
+func (d *bloomDict) stepper() stepper {
+	return &bloomStepper{d: d}
+}
+
+type bloomStepper struct {
+	d   *bloomDict
+	key []byte
+}
+
+func (s *bloomStepper) next(c byte) bool {
+	if len(s.key) == s.d.maxLen {
+		return false
+	}
+	s.key = append(s.key, c)
+	if !s.d.has(s.key) {
+		s.key = s.key[:len(s.key)-1]
+		return false
+	}
+	return true
+}
+
+func (s *bloomStepper) copy() stepper {
+	key := make([]byte, len(s.key), len(s.key)+1)
+	copy(key, s.key)
+	return &bloomStepper{d: s.d, key: key}
+}
+
+func (s *bloomStepper) word() (string, bool) {
+	if len(s.key) == 0 || !s.d.has(append(s.key, wordEnd)) {
+		return "", false
+	}
+	return s.d.keyWord(s.key), true
+}
@@ -0,0 +1,94 @@
+package solver
+
+// SolveLetterBudget generates all solutions for the given Boggle grid using
+// the rules of a variant where the letters on the board are a shared pool of
+// tiles, instead of using the rule that no square is used twice in a word.
+//
+// The exact rule is: a word is found if it is spelled by a path of adjacent
+// squares, where the path may visit a square more than once, and the word
+// uses each letter no more times than the number of squares on the board with
+// that letter. So, on a board with one E, a word with two E's is not found,
+// even if a path could visit the E twice. On a board with two E's, a path may
+// visit the same E square twice, since the word still uses only two E's. The
+// Qu tile is one letter of the pool, and words are otherwise spelled the same
+// as for Solve. A step from a square to itself is never allowed.
+//
+// The words are sorted unless the Solver was created using
+// WithSortResults(false). If the Solver was created using WithNodeLimit and
+// the limit is reached, then the words found so far are returned along with
+// ErrNodeLimit.
+func (s Solver) SolveLetterBudget(grid string) ([]string, error) {
+	board, err := s.board(grid)
+	if err != nil {
+		return nil, err
+	}
+	var budget [256]int
+	for sq := 0; sq < len(board); sq++ {
+		if s.mask != nil && !s.mask[sq] {
+			continue
+		}
+		if c := board[sq]; isLetter(c) {
+			budget[c]++
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sc := &Scratch{}
+	var words []string
+	// walk steps onto square sq, where st has stepped onto the letter at sq.
+	// Returns false if the search node limit is reached.
+	var walk func(sq int, st stepper) bool
+	walk = func(sq int, st stepper) bool {
+		if !s.explore(sc) {
+			return false
+		}
+		if w, ok := st.word(); ok {
+			if out, ok := s.output(w); ok {
+				words = append(words, out)
+			}
+		}
+		for _, next := range s.adj[sq] {
+			if !isLetter(board[next]) {
+				continue // blank square
+			}
+			c := board[next]
+			if budget[c] == 0 {
+				continue
+			}
+			ns := st.copy()
+			if !ns.next(board[next]) {
+				continue
+			}
+			budget[c]--
+			ok := walk(next, ns)
+			budget[c]++
+			if !ok {
+				return false
+			}
+		}
+		return true
+	}
+	for initSq := 0; initSq < len(board); initSq++ {
+		if s.mask != nil && !s.mask[initSq] {
+			continue
+		}
+		st := s.dict.stepper()
+		if !st.next(board[initSq]) {
+			continue
+		}
+		c := board[initSq]
+		budget[c]--
+		ok := walk(initSq, st)
+		budget[c]++
+		if !ok {
+			break
+		}
+	}
+
+	if s.cfg.unsorted {
+		return uniqueWords(words, nil), sc.nodeErr()
+	}
+	return uniqueSortedWords(words), sc.nodeErr()
+}
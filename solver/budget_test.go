@@ -0,0 +1,44 @@
+package solver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSolveLetterBudget(t *testing.T) {
+	s, err := New(4, 1, writeWords(t, "ata", "eta", "tete", "teat"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// +---+---+---+---+
+	// | A | E | T | A |
+	// +---+---+---+---+
+	grid := "aeta"
+	words, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"eta"}) {
+		t.Fatal("wrong solutions:", words)
+	}
+
+	// The path of "ata" visits the last A twice, which the board's two A's
+	// allow. The path of "tete" visits the T and E twice, but the board has
+	// only one of each.
+	words, err = s.SolveLetterBudget(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"ata", "eta"}) {
+		t.Fatal("wrong solutions with letter budget:", words)
+	}
+
+	// With two T's and two E's, "tete" is found.
+	words, err = s.SolveLetterBudget("tete")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"tete"}) {
+		t.Fatal("wrong solutions with letter budget:", words)
+	}
+}
@@ -0,0 +1,62 @@
+package solver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SolveCells generates all solutions for a Boggle board given as rows of
+// cells, the same as Solve. Each cell is a single letter, or "qu" for the Qu
+// tile, in any case. A cell that is empty is blocked, and no word passes
+// through it. This draws boards of any shape, such as a cross or a diamond,
+// within the Solver's rectangle of squares, without needing a Solver created
+// by NewMasked for each shape.
+//
+// The rows are in the same order as the rows of a grid string, beginning
+// with the top row, or the bottom row if the Solver was created using
+// WithOrigin(BottomLeft). The number of rows and the length of each row must
+// match the Solver's dimensions, otherwise an error wrapping
+// ErrInvalidDimensions is returned.
+func (s Solver) SolveCells(cells [][]string) ([]string, error) {
+	board, err := s.cellsBoard(cells)
+	if err != nil {
+		return nil, err
+	}
+	return s.solveBoard(board, &Scratch{}, nil)
+}
+
+// cellsBoard converts rows of cells into the board to search, with blocked
+// cells as padSquare.
+func (s Solver) cellsBoard(cells [][]string) (string, error) {
+	if s.dict == nil {
+		return "", ErrNoDictionary
+	}
+	if len(cells) != s.rows {
+		return "", fmt.Errorf("%w: have %d rows, need %d", ErrInvalidDimensions, len(cells), s.rows)
+	}
+	board := make([]byte, 0, s.BoardSize())
+	for y, row := range cells {
+		if len(row) != s.cols {
+			return "", fmt.Errorf("%w: row %d has %d cells, need %d", ErrInvalidDimensions, y, len(row), s.cols)
+		}
+		for x, cell := range row {
+			sq := len(board)
+			if cell == "" || (s.mask != nil && !s.mask[sq]) {
+				board = append(board, padSquare)
+				continue
+			}
+			c := cell
+			if !s.cfg.caseSensitive {
+				c = strings.ToLower(cell)
+			}
+			if len(c) == 2 && (c[0] == 'q' || c[0] == 'Q') && (c[1] == 'u' || c[1] == 'U') {
+				c = c[:1]
+			}
+			if len(c) != 1 || !isLetter(c[0]) {
+				return "", fmt.Errorf("%w: %q at row %d, column %d", ErrInvalidCharacter, cell, y, x)
+			}
+			board = append(board, c[0])
+		}
+	}
+	return string(board), nil
+}
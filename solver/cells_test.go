@@ -0,0 +1,71 @@
+package solver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSolveCells(t *testing.T) {
+	s, err := New(3, 3, writeWords(t, "cat", "tea", "act", "quit", "ate", "eta", "acts", "cast"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Plus-shaped board, with blocked corners:
+	//     +---+
+	//     | C |
+	// +---+---+---+
+	// | T | A | E |
+	// +---+---+---+
+	//     | T |
+	//     +---+
+	cells := [][]string{
+		{"", "c", ""},
+		{"T", "a", "e"},
+		{"", "t", ""},
+	}
+	words, err := s.SolveCells(cells)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []string{"act", "ate", "cat", "eta", "tea"}
+	if !reflect.DeepEqual(words, expect) {
+		t.Fatalf("expected %v, got %v", expect, words)
+	}
+
+	// Filling a corner adds words through it.
+	cells[0][0] = "s"
+	words, err = s.SolveCells(cells)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect = []string{"act", "acts", "ate", "cast", "cat", "eta", "tea"}
+	if !reflect.DeepEqual(words, expect) {
+		t.Fatalf("expected %v, got %v", expect, words)
+	}
+
+	words, err = s.SolveCells([][]string{
+		{"Qu", "i", ""},
+		{"", "", "t"},
+		{"", "", ""},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"quit"}) {
+		t.Fatal("expected quit, got", words)
+	}
+
+	_, err = s.SolveCells([][]string{{"a", "b", "c"}, {"d", "e"}, {"f", "g", "h"}})
+	if !errors.Is(err, ErrInvalidDimensions) {
+		t.Error("expected ErrInvalidDimensions for short row, got", err)
+	}
+	_, err = s.SolveCells([][]string{{"a", "b", "c"}, {"d", "e", "f"}})
+	if !errors.Is(err, ErrInvalidDimensions) {
+		t.Error("expected ErrInvalidDimensions for missing row, got", err)
+	}
+	_, err = s.SolveCells([][]string{{"a", "b", "c"}, {"d", "ef", "g"}, {"h", "i", "j"}})
+	if !errors.Is(err, ErrInvalidCharacter) {
+		t.Error("expected ErrInvalidCharacter, got", err)
+	}
+}
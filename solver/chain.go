@@ -0,0 +1,95 @@
+package solver
+
+import "slices"
+
+// WordChain is a path through a board that spells a sequence of words.
+type WordChain struct {
+	// Words are the words spelled along the path, in order.
+	Words []string
+	// Path is the squares visited, in order, to spell the words.
+	Path []int
+}
+
+// LongestChain finds the longest chain of words in the given Boggle grid. A
+// chain is a single path through the board, which visits no square more than
+// once, that spells one or more dictionary words one after another. Each word
+// after the first begins on a square adjacent to the last square of the word
+// before it. The longest chain is the one whose path has the most squares. If
+// more than one chain has the most squares, the one found first is returned.
+// If no word is found, then the returned chain is empty.
+//
+// This searches every path through the board that continues or begins a
+// dictionary word, which is much more work than Solve. Use WithNodeLimit to
+// bound the work, in which case the longest chain found before reaching the
+// limit is returned along with ErrNodeLimit.
+func (s Solver) LongestChain(grid string) (WordChain, error) {
+	board, err := s.board(grid)
+	if err != nil {
+		return WordChain{}, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sc := &Scratch{}
+	visited := make([]bool, len(board))
+	var path []int
+	var words []string
+	var best WordChain
+
+	// walk extends the chain onto square sq, where st has stepped onto the
+	// letter at sq. Returns false if the search node limit is reached.
+	var walk func(sq int, st stepper) bool
+	walk = func(sq int, st stepper) bool {
+		if !s.explore(sc) {
+			return false
+		}
+		visited[sq] = true
+		path = append(path, sq)
+		w, ok := st.word()
+		if ok {
+			w, ok = s.output(w)
+		}
+		if ok {
+			words = append(words, w)
+			if len(path) > len(best.Path) {
+				best.Words = slices.Clone(words)
+				best.Path = slices.Clone(path)
+			}
+			// Begin the next word on an adjacent square.
+			for _, next := range s.adj[sq] {
+				if visited[next] {
+					continue
+				}
+				ns := s.dict.stepper()
+				if ns.next(board[next]) && !walk(next, ns) {
+					return false
+				}
+			}
+			words = words[:len(words)-1]
+		}
+		// Continue the current word on an adjacent square.
+		for _, next := range s.adj[sq] {
+			if visited[next] {
+				continue
+			}
+			ns := st.copy()
+			if ns.next(board[next]) && !walk(next, ns) {
+				return false
+			}
+		}
+		visited[sq] = false
+		path = path[:len(path)-1]
+		return true
+	}
+
+	for initSq := 0; initSq < len(board); initSq++ {
+		if s.mask != nil && !s.mask[initSq] {
+			continue
+		}
+		st := s.dict.stepper()
+		if st.next(board[initSq]) && !walk(initSq, st) {
+			break
+		}
+	}
+	return best, sc.nodeErr()
+}
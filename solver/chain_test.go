@@ -0,0 +1,67 @@
+package solver
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLongestChain(t *testing.T) {
+	s, err := New(3, 2, writeWords(t, "cat", "sea", "tea", "eat"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// +---+---+---+
+	// | C | A | T |
+	// +---+---+---+
+	// | A | E | S |
+	// +---+---+---+
+	chain, err := s.LongestChain("cataes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := WordChain{
+		Words: []string{"cat", "sea"},
+		Path:  []int{0, 1, 2, 5, 4, 3},
+	}
+	if !reflect.DeepEqual(chain, expect) {
+		t.Fatalf("expected %v, got %v", expect, chain)
+	}
+
+	// The chain spells its words along a path of adjacent squares.
+	var spelled strings.Builder
+	for _, sq := range chain.Path {
+		spelled.WriteByte("cataes"[sq])
+	}
+	if spelled.String() != strings.Join(chain.Words, "") {
+		t.Fatal("chain path does not spell words")
+	}
+	for _, dir := range s.DirectionsFor(chain.Path) {
+		if dir == "" {
+			t.Fatal("chain path has squares that are not adjacent")
+		}
+	}
+
+	chain, err = s.LongestChain("xxxxxx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chain.Words) != 0 || len(chain.Path) != 0 {
+		t.Fatal("expected empty chain, got", chain)
+	}
+}
+
+func TestLongestChainNodeLimit(t *testing.T) {
+	s, err := New(4, 4, writeWords(t, "eee"), WithNodeLimit(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain, err := s.LongestChain(strings.Repeat("e", 16))
+	if !errors.Is(err, ErrNodeLimit) {
+		t.Fatal("expected ErrNodeLimit, got", err)
+	}
+	if len(chain.Path) == 0 || len(chain.Path) != 3*len(chain.Words) {
+		t.Fatal("expected partial chain, got", chain)
+	}
+}
@@ -0,0 +1,96 @@
+package solver
+
+import "fmt"
+
+// DictionaryDiff describes how the solutions for a grid differ when solved
+// using two different dictionaries.
+type DictionaryDiff struct {
+	// OnlyA are the words found only using the first dictionary.
+	OnlyA []string
+	// OnlyB are the words found only using the second dictionary.
+	OnlyB []string
+	// Common are the words found using both dictionaries.
+	Common []string
+	// ScoreA and ScoreB are the total points, as returned by TotalScore, of
+	// the words found using the first and second dictionaries.
+	ScoreA, ScoreB int
+	// ScoreDiff is ScoreB minus ScoreA, which is positive if the second
+	// dictionary scores more points on the grid.
+	ScoreDiff int
+}
+
+// DiffWords compares two lists of words and returns the words only in a, the
+// words only in b, and the words in both. Each returned list is sorted and
+// contains no duplicates. The given lists are not modified.
+func DiffWords(a, b []string) (onlyA, onlyB, common []string) {
+	a = uniqueSortedWords(append([]string(nil), a...))
+	b = uniqueSortedWords(append([]string(nil), b...))
+
+	var i, j int
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			onlyA = append(onlyA, a[i])
+			i++
+		case a[i] > b[j]:
+			onlyB = append(onlyB, b[j])
+			j++
+		default:
+			common = append(common, a[i])
+			i++
+			j++
+		}
+	}
+	onlyA = append(onlyA, a[i:]...)
+	onlyB = append(onlyB, b[j:]...)
+	return onlyA, onlyB, common
+}
+
+// CompareDictionaries solves the grid using two Solvers, which differ only in
+// the dictionary they were created with, and returns the difference in their
+// solutions and total score. This helps evaluate the quality of one word list
+// against another. The Solvers must have the same dimensions.
+func CompareDictionaries(a, b Solver, grid string) (DictionaryDiff, error) {
+	if a.cols != b.cols || a.rows != b.rows {
+		return DictionaryDiff{}, fmt.Errorf("%w: %dx%d does not match %dx%d",
+			ErrInvalidDimensions, a.cols, a.rows, b.cols, b.rows)
+	}
+	wordsA, err := a.Solve(grid)
+	if err != nil {
+		return DictionaryDiff{}, err
+	}
+	wordsB, err := b.Solve(grid)
+	if err != nil {
+		return DictionaryDiff{}, err
+	}
+
+	var diff DictionaryDiff
+	diff.OnlyA, diff.OnlyB, diff.Common = DiffWords(wordsA, wordsB)
+	diff.ScoreA = TotalScore(wordsA)
+	diff.ScoreB = TotalScore(wordsB)
+	diff.ScoreDiff = diff.ScoreB - diff.ScoreA
+	return diff, nil
+}
+
+// BoardSimilarity solves two grids and returns the Jaccard index of their
+// solutions: the number of words found in both grids divided by the number of
+// words found in either grid. This is 1 for grids with the same solutions, and
+// 0 for grids with no solutions in common. Two grids that both have no
+// solutions have a similarity of 1.
+func (s Solver) BoardSimilarity(gridA, gridB string) (float64, error) {
+	wordsA, err := s.Solve(gridA)
+	if err != nil {
+		return 0, err
+	}
+	wordsB, err := s.Solve(gridB)
+	if err != nil {
+		return 0, err
+	}
+
+	onlyA, onlyB, common := DiffWords(wordsA, wordsB)
+	union := len(onlyA) + len(onlyB) + len(common)
+	if union == 0 {
+		return 1, nil
+	}
+	return float64(len(common)) / float64(union), nil
+}
@@ -0,0 +1,107 @@
+package solver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDiffWords(t *testing.T) {
+	a := []string{"delta", "alpha", "gamma", "alpha"}
+	b := []string{"beta", "gamma", "delta", "zeta"}
+	onlyA, onlyB, common := DiffWords(a, b)
+	if !reflect.DeepEqual(onlyA, []string{"alpha"}) {
+		t.Error("wrong words only in a:", onlyA)
+	}
+	if !reflect.DeepEqual(onlyB, []string{"beta", "zeta"}) {
+		t.Error("wrong words only in b:", onlyB)
+	}
+	if !reflect.DeepEqual(common, []string{"delta", "gamma"}) {
+		t.Error("wrong common words:", common)
+	}
+	if a[0] != "delta" {
+		t.Error("input modified")
+	}
+}
+
+func TestCompareDictionaries(t *testing.T) {
+	a, err := New(3, 3, writeWords(t, "act", "cat", "eat", "set", "tea"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := New(3, 3, writeWords(t, "cat", "eat", "tax", "tea", "axe", "taxes"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := CompareDictionaries(a, b, "xcxatsxex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(diff.OnlyA, []string{"act", "set"}) {
+		t.Error("wrong words only in a:", diff.OnlyA)
+	}
+	if !reflect.DeepEqual(diff.OnlyB, []string{"axe", "tax", "taxes"}) {
+		t.Error("wrong words only in b:", diff.OnlyB)
+	}
+	if !reflect.DeepEqual(diff.Common, []string{"cat", "eat", "tea"}) {
+		t.Error("wrong common words:", diff.Common)
+	}
+	// Each word scores 1 point, except "taxes", which scores 2.
+	if diff.ScoreA != 5 || diff.ScoreB != 7 || diff.ScoreDiff != 2 {
+		t.Errorf("wrong scores: a %d, b %d, diff %d", diff.ScoreA, diff.ScoreB, diff.ScoreDiff)
+	}
+
+	c, err := New(4, 4, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = CompareDictionaries(a, c, "xcxatsxex")
+	if !errors.Is(err, ErrInvalidDimensions) {
+		t.Fatal("expected ErrInvalidDimensions, got", err)
+	}
+}
+
+func TestBoardSimilarity(t *testing.T) {
+	s, err := New(3, 1, writeWords(t, "act", "cat", "tac", "eat", "tea", "ate"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		a, b   string
+		expect float64
+	}{
+		{"cat", "cat", 1},
+		{"cat", "tac", 1},
+		{"cat", "tea", 0},
+		{"xxx", "yyy", 1},
+	}
+	for _, tc := range tests {
+		sim, err := s.BoardSimilarity(tc.a, tc.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sim != tc.expect {
+			t.Errorf("expected similarity %v for %q and %q, got %v", tc.expect, tc.a, tc.b, sim)
+		}
+	}
+
+	// On a 2x2 board, "catx" has act, cat, and tac, and "caet" has those
+	// words and ate, eat, and tea.
+	s, err = New(2, 2, writeWords(t, "act", "cat", "tac", "eat", "tea", "ate"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim, err := s.BoardSimilarity("catx", "caet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sim != 0.5 {
+		t.Error("expected similarity 0.5, got", sim)
+	}
+
+	if _, err = s.BoardSimilarity("catx", "ca"); !errors.Is(err, ErrGridTooShort) {
+		t.Error("expected ErrGridTooShort, got", err)
+	}
+}
@@ -0,0 +1,355 @@
+package solver
+
+import (
+	"slices"
+	"strings"
+	"unsafe"
+
+	"github.com/gammazero/radixtree"
+)
+
+// Backend selects the data structure that holds the dictionary searched by a
+// Solver.
+type Backend int
+
+const (
+	// RadixTree holds the dictionary in a radix tree. This is the default.
+	RadixTree Backend = iota
+	// CompactTrie holds the dictionary in a trie stored in flat arrays. This
+	// uses much less memory than RadixTree for large dictionaries, at the cost
+	// of more time to build the dictionary when the Solver is created.
+	CompactTrie
+	// BloomFilter holds the dictionary in a bloom filter, which uses the
+	// least memory, for devices with little memory to spare. A bloom filter
+	// does not store the words, and only tests whether a word is probably in
+	// the dictionary, so Solve may return a word that is not in the
+	// dictionary. Every word that is in the dictionary is found. The chance
+	// of a false word is set by WithFalsePositiveRate.
+	BloomFilter
+)
+
+// dictionary is the set of words that a Solver searches for on a board.
+type dictionary interface {
+	// Len returns the number of words in the dictionary.
+	Len() int
+	// stepper returns a stepper at the root of the dictionary.
+	stepper() stepper
+	// memory returns an estimate of the memory used by the dictionary.
+	memory() MemoryEstimate
+	// walk calls fn with the key and word of each word whose key begins with
+	// the prefix.
+	walk(prefix string, fn func(key, word string))
+}
+
+// MemoryEstimate is an approximate measure of the memory used by a dictionary.
+type MemoryEstimate struct {
+	// Nodes is the number of nodes in the dictionary's tree. The BloomFilter
+	// backend has no tree, and counts the nodes that a trie of its words would
+	// have.
+	Nodes int
+	// Bytes is the approximate number of bytes used by the nodes and words.
+	Bytes int
+}
+
+// Approximate sizes, in bytes, of the parts of a radix tree, used to estimate
+// the memory it uses. A node holds its prefix, edges to its children, and a
+// leaf if a word ends at the node. A leaf holds the key and word strings.
+const (
+	radixNodeBytes = 64
+	radixEdgeBytes = 16
+	radixLeafBytes = 48
+)
+
+// MemoryEstimate returns an estimate of the memory used by the Solver's
+// dictionary, including the reverse index if there is one. This helps budget
+// memory for many Solvers. The estimate is approximate: it counts the nodes of
+// the dictionary's tree and the words stored in it, but not overhead such as
+// unused slice capacity and memory allocator rounding, and the size of each
+// radix tree node is an average.
+func (s Solver) MemoryEstimate() MemoryEstimate {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.dict == nil {
+		return MemoryEstimate{}
+	}
+	m := s.dict.memory()
+	if s.rev != nil {
+		r := s.rev.memory()
+		m.Nodes += r.Nodes
+		m.Bytes += r.Bytes
+	}
+	return m
+}
+
+// stepper steps through the words of a dictionary one letter at a time.
+type stepper interface {
+	// next steps to the given letter, and returns false if no word continues
+	// with the letter.
+	next(c byte) bool
+	// copy returns an independent copy of the stepper.
+	copy() stepper
+	// word returns the dictionary word that ends at the stepper's position,
+	// and returns false if no word ends there.
+	word() (string, bool)
+}
+
+// newDictionary creates the dictionary for the backend set in cfg from the
+// tree of loaded words. If reversed is true, then the keys of the tree are
+// reversed words, as in a reverse index.
+func newDictionary(tree *radixtree.Tree, cfg config, reversed bool) dictionary {
+	switch cfg.backend {
+	case CompactTrie:
+		return newCompactTrie(tree)
+	case BloomFilter:
+		return newBloomDict(tree, cfg.fpRate, cfg.caseSensitive, reversed)
+	}
+	return radixDict{tree}
+}
+
+// lookup returns the dictionary word stored under the given key, and true if
+// there is one.
+func (s Solver) lookup(key string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	st := s.dict.stepper()
+	for i := 0; i < len(key); i++ {
+		if !st.next(key[i]) {
+			return "", false
+		}
+	}
+	return st.word()
+}
+
+// radixDict is a dictionary held in a radix tree, where each key is a
+// normalized word and each value is the original word.
+type radixDict struct {
+	*radixtree.Tree
+}
+
+// memory estimates the memory used by the radix tree from its keys. In a radix
+// tree, there is a node for each key and for each other prefix where keys
+// branch apart. In sorted order, the prefixes where keys branch apart are the
+// longest common prefixes of neighboring keys.
+func (d radixDict) memory() MemoryEstimate {
+	keys := make([]string, 0, d.Len())
+	var wordBytes int
+	d.Walk("", func(key string, value any) bool {
+		keys = append(keys, key)
+		wordBytes += len(key) + len(value.(string))
+		return false
+	})
+	slices.Sort(keys)
+
+	branches := make(map[string]struct{})
+	for i := 1; i < len(keys); i++ {
+		a, b := keys[i-1], keys[i]
+		n := 0
+		for n < len(a) && n < len(b) && a[n] == b[n] {
+			n++
+		}
+		if _, isKey := slices.BinarySearch(keys, a[:n]); !isKey {
+			branches[a[:n]] = struct{}{}
+		}
+	}
+	// The root is the branch with an empty prefix, if there is one.
+	nodes := len(keys) + len(branches)
+	if _, ok := branches[""]; !ok {
+		nodes++
+	}
+	return MemoryEstimate{
+		Nodes: nodes,
+		// Each node except the root is reached by one edge.
+		Bytes: nodes*radixNodeBytes + (nodes-1)*radixEdgeBytes +
+			len(keys)*radixLeafBytes + wordBytes,
+	}
+}
+
+func (d radixDict) walk(prefix string, fn func(key, word string)) {
+	d.Walk(prefix, func(key string, value any) bool {
+		fn(key, value.(string))
+		return false
+	})
+}
+
+func (d radixDict) stepper() stepper {
+	return radixStepper{d.NewStepper()}
+}
+
+type radixStepper struct {
+	st *radixtree.Stepper
+}
+
+func (r radixStepper) next(c byte) bool {
+	return r.st.Next(c)
+}
+
+func (r radixStepper) copy() stepper {
+	return radixStepper{r.st.Copy()}
+}
+
+func (r radixStepper) word() (string, bool) {
+	item := r.st.Item()
+	if item == nil {
+		return "", false
+	}
+	return item.Value().(string), true
+}
+
+// compactTrie is a dictionary held in a trie stored in flat arrays. Nodes are
+// stored in breadth-first order, so that the children of each node are next to
+// each other and are found by scanning a small range of the nodes array. This
+// avoids the per-node allocations and maps of a pointer-based tree.
+type compactTrie struct {
+	nodes []compactNode
+	// words holds all words concatenated, and ends holds the offset in words
+	// where each word ends.
+	words string
+	ends  []uint32
+}
+
+type compactNode struct {
+	// first is the index of the node's first child.
+	first uint32
+	// count is the number of children.
+	count uint16
+	// label is the letter that steps to this node from its parent.
+	label byte
+	// word is the index of the word ending at this node, or -1.
+	word int32
+}
+
+// newCompactTrie creates a compactTrie holding the same words as the tree.
+func newCompactTrie(tree *radixtree.Tree) *compactTrie {
+	type entry struct {
+		key, word string
+	}
+	entries := make([]entry, 0, tree.Len())
+	tree.Walk("", func(key string, value any) bool {
+		entries = append(entries, entry{key, value.(string)})
+		return false
+	})
+	slices.SortFunc(entries, func(a, b entry) int {
+		return strings.Compare(a.key, b.key)
+	})
+
+	t := &compactTrie{
+		ends: make([]uint32, 0, len(entries)),
+	}
+	var words strings.Builder
+
+	// Each node is built from the span of sorted entries whose keys begin
+	// with the node's prefix, which has depth letters. Node i is built from
+	// spans[i], and creating a child node appends the child's span.
+	type span struct {
+		lo, hi, depth int
+	}
+	spans := []span{{0, len(entries), 0}}
+	t.nodes = append(t.nodes, compactNode{word: -1})
+	for i := 0; i < len(spans); i++ {
+		sp := spans[i]
+		lo := sp.lo
+		if lo < sp.hi && len(entries[lo].key) == sp.depth {
+			t.nodes[i].word = int32(len(t.ends))
+			words.WriteString(entries[lo].word)
+			t.ends = append(t.ends, uint32(words.Len()))
+			lo++
+		}
+		first := len(t.nodes)
+		for lo < sp.hi {
+			c := entries[lo].key[sp.depth]
+			hi := lo + 1
+			for hi < sp.hi && entries[hi].key[sp.depth] == c {
+				hi++
+			}
+			t.nodes = append(t.nodes, compactNode{label: c, word: -1})
+			spans = append(spans, span{lo, hi, sp.depth + 1})
+			lo = hi
+		}
+		t.nodes[i].first = uint32(first)
+		t.nodes[i].count = uint16(len(t.nodes) - first)
+	}
+	t.nodes = slices.Clip(t.nodes)
+	t.words = words.String()
+	return t
+}
+
+func (t *compactTrie) Len() int {
+	return len(t.ends)
+}
+
+func (t *compactTrie) memory() MemoryEstimate {
+	return MemoryEstimate{
+		Nodes: len(t.nodes),
+		Bytes: len(t.nodes)*int(unsafe.Sizeof(compactNode{})) + len(t.words) +
+			len(t.ends)*int(unsafe.Sizeof(t.ends[0])),
+	}
+}
+
+func (t *compactTrie) walk(prefix string, fn func(key, word string)) {
+	st := &compactStepper{t: t}
+	for i := 0; i < len(prefix); i++ {
+		if !st.next(prefix[i]) {
+			return
+		}
+	}
+	key := []byte(prefix)
+	var visit func(node uint32)
+	visit = func(node uint32) {
+		n := t.nodes[node]
+		if n.word >= 0 {
+			fn(string(key), t.word(n.word))
+		}
+		for c := n.first; c < n.first+uint32(n.count); c++ {
+			key = append(key, t.nodes[c].label)
+			visit(c)
+			key = key[:len(key)-1]
+		}
+	}
+	visit(st.node)
+}
+
+// word returns the word with the given index.
+func (t *compactTrie) word(w int32) string {
+	var start uint32
+	if w != 0 {
+		start = t.ends[w-1]
+	}
+	return t.words[start:t.ends[w]]
+}
+
+func (t *compactTrie) stepper() stepper {
+	return &compactStepper{t: t}
+}
+
+type compactStepper struct {
+	t    *compactTrie
+	node uint32
+}
+
+func (s *compactStepper) next(c byte) bool {
+	n := s.t.nodes[s.node]
+	children := s.t.nodes[n.first : n.first+uint32(n.count)]
+	for i := range children {
+		if children[i].label == c {
+			s.node = n.first + uint32(i)
+			return true
+		}
+		if children[i].label > c {
+			break // children are sorted by label
+		}
+	}
+	return false
+}
+
+func (s *compactStepper) copy() stepper {
+	cp := *s
+	return &cp
+}
+
+func (s *compactStepper) word() (string, bool) {
+	w := s.t.nodes[s.node].word
+	if w < 0 {
+		return "", false
+	}
+	return s.t.word(w), true
+}
@@ -0,0 +1,266 @@
+package solver
+
+import (
+	"math/rand"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var backends = map[string]Backend{
+	"radixtree": RadixTree,
+	"compact":   CompactTrie,
+	"bloom":     BloomFilter,
+}
+
+func TestBloomFilter(t *testing.T) {
+	radix, err := New(4, 4, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bloom, err := New(4, 4, "", WithBackend(BloomFilter), WithFalsePositiveRate(0.01))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bloom.WordCount() != radix.WordCount() {
+		t.Fatal("wrong word count:", bloom.WordCount())
+	}
+	if bloom.MemoryEstimate().Bytes >= radix.MemoryEstimate().Bytes/4 {
+		t.Fatalf("bloom filter uses %d bytes, radix tree %d bytes",
+			bloom.MemoryEstimate().Bytes, radix.MemoryEstimate().Bytes)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	var total, falsePositives int
+	for i := 0; i < 50; i++ {
+		grid, err := FillGrid(strings.Repeat(string(FillPlaceholder), 16), 16, rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect, err := radix.Solve(grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		words, err := bloom.Solve(grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		onlyRadix, onlyBloom, _ := DiffWords(expect, words)
+		if len(onlyRadix) != 0 {
+			t.Fatalf("words not found for grid %s: %v", grid, onlyRadix)
+		}
+		total += len(words)
+		falsePositives += len(onlyBloom)
+	}
+	if falsePositives > total/10 {
+		t.Fatalf("too many false positives: %d of %d words", falsePositives, total)
+	}
+
+	// Words using the Qu tile are returned with the full spelling, including
+	// from the reverse index.
+	s, err := New(2, 2, writeWords(t, "quit", "qua", "tui"), WithBackend(BloomFilter), WithReverseIndex(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	words, err := s.Solve("qitx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"quit"}) {
+		t.Fatal("wrong words:", words)
+	}
+	words, err = s.SolveEndingAt("qitx", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"quit"}) {
+		t.Fatal("wrong words from reverse index:", words)
+	}
+	if r := s.RestrictPrefixes("qu"); r.WordCount() != 2 {
+		t.Fatal("wrong word count for restricted prefix:", r.WordCount())
+	}
+}
+
+func TestCompactTrie(t *testing.T) {
+	radix, err := New(4, 4, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	compact, err := New(4, 4, "", WithBackend(CompactTrie))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if compact.WordCount() != radix.WordCount() {
+		t.Fatal("wrong word count:", compact.WordCount())
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		grid, err := FillGrid(strings.Repeat(string(FillPlaceholder), 16), 16, rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect, err := radix.Solve(grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		words, err := compact.Solve(grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(words, expect) {
+			t.Fatalf("different words for grid %s: %v, expected %v", grid, words, expect)
+		}
+	}
+
+	// Words using the Qu tile are stored with the full spelling.
+	s, err := New(2, 2, writeWords(t, "quit", "qua", "tui"), WithBackend(CompactTrie))
+	if err != nil {
+		t.Fatal(err)
+	}
+	words, err := s.Solve("qitx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"quit"}) {
+		t.Fatal("wrong words:", words)
+	}
+}
+
+func TestBackendsMatch(t *testing.T) {
+	denyPath := writeWords(t, "quart", "tea")
+	solvers := make(map[string]Solver, len(backends))
+	for name, backend := range backends {
+		s, err := New(5, 5, "", WithBackend(backend), WithDenyFile(denyPath), WithWordCase(TitleCase))
+		if err != nil {
+			t.Fatal(err)
+		}
+		solvers[name] = s
+	}
+	radix := solvers["radixtree"]
+
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 20; i++ {
+		grid, err := FillGrid(strings.Repeat(string(FillPlaceholder), 25), 25, rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect, err := radix.SolvePaths(grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for name, s := range solvers {
+			if s.WordCount() != radix.WordCount() {
+				t.Fatalf("%s: wrong word count %d", name, s.WordCount())
+			}
+			paths, err := s.SolvePaths(grid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.cfg.backend == BloomFilter {
+				// The bloom filter also finds false positives, which are
+				// checked by TestBloomFilter. Drop them before comparing.
+				paths = slices.DeleteFunc(paths, func(wp WordPath) bool {
+					_, found := slices.BinarySearchFunc(expect, wp.Word, func(e WordPath, w string) int {
+						return strings.Compare(e.Word, w)
+					})
+					return !found
+				})
+			}
+			if !reflect.DeepEqual(paths, expect) {
+				t.Fatalf("%s: different solutions for grid %s", name, grid)
+			}
+		}
+	}
+}
+
+func TestMemoryEstimate(t *testing.T) {
+	for name, backend := range backends {
+		small, err := New(4, 4, writeWords(t, "cat", "cats", "car", "cab"), WithBackend(backend))
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := small.MemoryEstimate()
+		// Radix tree: root, ca, cab, car, cat, cats.
+		// Compact trie: root, c, a, b, r, t, s.
+		// Bloom filter: root and prefixes c, ca, cab, car, cat, cats.
+		expectNodes := map[string]int{"radixtree": 6, "compact": 7, "bloom": 7}[name]
+		if m.Nodes != expectNodes {
+			t.Errorf("%s: expected %d nodes, got %d", name, expectNodes, m.Nodes)
+		}
+
+		// Load the large dictionary once, with a reverse index, and compare
+		// the estimate of the forward dictionary alone to the total.
+		rev, err := New(4, 4, "", WithBackend(backend), WithReverseIndex(true))
+		if err != nil {
+			t.Fatal(err)
+		}
+		lm := rev.dict.memory()
+		if lm.Nodes < 10000 || lm.Bytes < 1000*m.Bytes {
+			t.Errorf("%s: estimate did not scale with dictionary: small %+v, large %+v", name, m, lm)
+		}
+		if rm := rev.MemoryEstimate(); rm.Nodes <= lm.Nodes || rm.Bytes <= lm.Bytes {
+			t.Errorf("%s: estimate does not include reverse index: %+v", name, rm)
+		}
+	}
+}
+
+func BenchmarkBackendNew(b *testing.B) {
+	for name, backend := range backends {
+		b.Run(name, func(b *testing.B) {
+			var s Solver
+			var before, after runtime.MemStats
+			runtime.GC()
+			runtime.ReadMemStats(&before)
+			for i := 0; i < b.N; i++ {
+				s, _ = New(4, 4, "", WithBackend(backend))
+			}
+			b.StopTimer()
+			runtime.GC()
+			runtime.ReadMemStats(&after)
+			// Report the memory held by the last Solver created.
+			b.ReportMetric(float64(after.HeapAlloc-before.HeapAlloc), "heap-bytes")
+			runtime.KeepAlive(s)
+		})
+	}
+}
+
+func BenchmarkBackendSolve(b *testing.B) {
+	for name, backend := range backends {
+		b.Run(name, func(b *testing.B) {
+			const xlen = 50
+			const ylen = 50
+			s, _ := New(xlen, ylen, "", WithBackend(backend))
+			grid := genGrid(s.BoardSize())
+			var sc Scratch
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				s.SolveWithScratch(grid, &sc)
+			}
+		})
+	}
+}
+
+func BenchmarkBackendSolve4x4(b *testing.B) {
+	for name, backend := range backends {
+		b.Run(name, func(b *testing.B) {
+			s, _ := New(4, 4, "", WithBackend(backend))
+			rng := rand.New(rand.NewSource(1))
+			grids := make([]string, 100)
+			for i := range grids {
+				grids[i], _ = FillGrid(strings.Repeat(string(FillPlaceholder), 16), 16, rng)
+			}
+			var sc Scratch
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				s.SolveWithScratch(grids[i%len(grids)], &sc)
+			}
+		})
+	}
+}
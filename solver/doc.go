@@ -24,4 +24,21 @@
 //	+---+---+---+---+
 //
 // This grid has 62 unique solutions using the default dictionary.
+//
+// # Concurrency
+//
+// A Solver is safe for concurrent use by multiple goroutines, including while
+// its dictionary is changed by AddWord, RemoveWord, ReloadWords, or
+// ApplyUpdates. Copies of a Solver share its dictionary, and the lock that
+// guards the dictionary. A solve takes a read lock while searching from each
+// square of the board, and a change takes a write lock. So, a change waits for
+// searches from the current squares to finish, and searches wait for a change
+// to finish. A solve that runs while the dictionary changes may find some
+// words using the dictionary from before the change, and other words using the
+// dictionary after the change.
+//
+// Solve searches a large board using multiple goroutines, as set by
+// WithConcurrency, each searching from a share of the board's squares. The
+// words found are the same, and in the same order, as when searching using one
+// goroutine.
 package solver
@@ -0,0 +1,43 @@
+package solver
+
+import "errors"
+
+// Errors returned by the solver. These are wrapped with additional context,
+// so use errors.Is to test for them.
+var (
+	// ErrGridTooShort is returned when a grid has fewer letters than the
+	// board has squares.
+	ErrGridTooShort = errors.New("not enough letters for board")
+	// ErrGridTooLong is returned when a grid has more letters than the board
+	// has squares.
+	ErrGridTooLong = errors.New("too many letters for board")
+	// ErrInvalidDimensions is returned when board dimensions are not valid.
+	ErrInvalidDimensions = errors.New("invalid board dimensions")
+	// ErrInvalidCharacter is returned when a grid contains a character that
+	// is not a letter.
+	ErrInvalidCharacter = errors.New("invalid character in grid")
+	// ErrInvalidSquare is returned when a square index is not on the board.
+	ErrInvalidSquare = errors.New("square not on board")
+	// ErrNoDictionary is returned when a Solver has no dictionary to search.
+	ErrNoDictionary = errors.New("failed to read words file")
+	// ErrEmptyDictionary is returned when a words file has words, but none of
+	// them are usable for the board, such as when all words are too short.
+	ErrEmptyDictionary = errors.New("no usable words in words file")
+	// ErrNodeLimit is returned, with incomplete results, when a solve stops
+	// after exploring the number of search nodes set by WithNodeLimit.
+	ErrNodeLimit = errors.New("search node limit reached")
+	// ErrChecksum is returned when the checksum of a puzzle in text form does
+	// not match the puzzle, such as when the text was changed or cut short.
+	ErrChecksum = errors.New("puzzle checksum mismatch")
+	// ErrBoardTooLarge is returned when board dimensions give more squares
+	// than the maximum set by WithMaxBoardSize.
+	ErrBoardTooLarge = errors.New("board too large")
+	// ErrNoPath is returned when a word cannot be traced on a board.
+	ErrNoPath = errors.New("word not on board")
+	// ErrInvalidPath is returned when a path visits a square more than once,
+	// or steps between squares that are not adjacent.
+	ErrInvalidPath = errors.New("invalid path")
+	// ErrInvalidWordLength is returned when the minimum or maximum word length
+	// set by WithMinLength or WithMaxLength is not possible for the board.
+	ErrInvalidWordLength = errors.New("invalid word length")
+)
@@ -0,0 +1,291 @@
+package solver
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SolveExcluding generates all solutions for the given Boggle grid, the same
+// as Solve, except that any of the excluded words are not returned. This is
+// useful to leave out words that are already claimed by players.
+//
+// Excluded words are matched without regard to case, so excluding "QUEEN"
+// removes "queen" from the solutions, whatever the Solver's word case.
+func (s Solver) SolveExcluding(grid string, exclude []string) ([]string, error) {
+	words, err := s.Solve(grid)
+	if err != nil || len(exclude) == 0 {
+		return words, err
+	}
+	excluded := make(map[string]struct{}, len(exclude))
+	for _, w := range exclude {
+		excluded[strings.ToLower(w)] = struct{}{}
+	}
+	return filterWords(words, func(word string) bool {
+		_, ok := excluded[strings.ToLower(word)]
+		return !ok
+	}), nil
+}
+
+// WordSet is a set of words, such as a list of common words, used to exclude
+// words from solutions. Words are stored in lower case.
+type WordSet map[string]struct{}
+
+// NewWordSet returns a WordSet holding the given words.
+func NewWordSet(words []string) WordSet {
+	set := make(WordSet, len(words))
+	for _, w := range words {
+		if w != "" {
+			set[strings.ToLower(w)] = struct{}{}
+		}
+	}
+	return set
+}
+
+// LoadWordSet reads a file of line-delimited words into a WordSet. The file
+// may be gzipped, the same as the words file given to New. Unlike the words
+// file, words are not filtered by length or case, so that the set is not tied
+// to any board size.
+func LoadWordSet(filePath string) (WordSet, error) {
+	set := make(WordSet)
+	err := scanWords(filePath, func(word string) {
+		if word != "" {
+			set[strings.ToLower(word)] = struct{}{}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// Contains returns true if the set holds the word, without regard to case.
+func (ws WordSet) Contains(word string) bool {
+	_, ok := ws[strings.ToLower(word)]
+	return ok
+}
+
+// SolveNotIn generates all solutions for the given Boggle grid, the same as
+// Solve, except that words in the exclude set are not returned. Given a set of
+// common words, this finds only the unusual words on the board.
+//
+// Words are matched without regard to case, and as spelled in full, so the set
+// must hold "queen" to exclude the word spelled with the Qu tile.
+func (s Solver) SolveNotIn(grid string, exclude WordSet) ([]string, error) {
+	words, err := s.Solve(grid)
+	if err != nil || len(exclude) == 0 {
+		return words, err
+	}
+	return filterWords(words, func(word string) bool {
+		return !exclude.Contains(word)
+	}), nil
+}
+
+// SolveMatching generates all solutions for the given Boggle grid, the same as
+// Solve, except that only the words matched by re are returned. For example,
+// the expression "ing$" finds words ending in "ing". The expression is applied
+// to each word as returned by Solve, spelled in full and in the Solver's word
+// case, so a word using the Qu tile is matched as "queen" and not "qeen".
+func (s Solver) SolveMatching(grid string, re *regexp.Regexp) ([]string, error) {
+	words, err := s.Solve(grid)
+	if err != nil {
+		return nil, err
+	}
+	return filterWords(words, re.MatchString), nil
+}
+
+// StudyOrder returns a copy of the words sorted from most to least frequent,
+// according to the given frequencies, such as counts of each word in a body of
+// text. Words with the same frequency are sorted alphabetically, and words
+// that are not in freq are last, also sorted alphabetically.
+//
+// The keys of freq are words in lower case, spelled in full as returned by
+// Solve, such as "queen". Words are looked up without regard to case.
+func StudyOrder(words []string, freq map[string]int) []string {
+	type entry struct {
+		word  string
+		freq  int
+		known bool
+	}
+	entries := make([]entry, len(words))
+	for i, w := range words {
+		f, ok := freq[strings.ToLower(w)]
+		entries[i] = entry{w, f, ok}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.known != b.known {
+			return a.known
+		}
+		if a.freq != b.freq {
+			return a.freq > b.freq
+		}
+		return a.word < b.word
+	})
+	ordered := make([]string, len(entries))
+	for i, e := range entries {
+		ordered[i] = e.word
+	}
+	return ordered
+}
+
+// Frequency returns the frequency of the word given in the words file, if the
+// Solver was created using WithFrequencyColumn. Zero is returned for a word
+// with no frequency given, and for a word that is not in the dictionary. The
+// word is looked up without regard to case, unless the Solver was created
+// using WithCaseSensitive, and spelled in full, such as "queen".
+func (s Solver) Frequency(word string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.freq[s.foldCase(word)]
+}
+
+// foldCase returns the word in lower case, unless the Solver is case
+// sensitive.
+func (s Solver) foldCase(word string) string {
+	if s.cfg.caseSensitive {
+		return word
+	}
+	return strings.ToLower(word)
+}
+
+// SolveRarest generates all solutions for the given Boggle grid, the same as
+// Solve, and returns them sorted from least to most frequent, using the
+// frequencies from the words file given by WithFrequencyColumn. Words with the
+// same frequency are sorted alphabetically. This puts the most unusual finds
+// first.
+func (s Solver) SolveRarest(grid string) ([]string, error) {
+	words, err := s.Solve(grid)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(words, func(i, j int) bool {
+		fi, fj := s.Frequency(words[i]), s.Frequency(words[j])
+		if fi != fj {
+			return fi < fj
+		}
+		return words[i] < words[j]
+	})
+	return words, nil
+}
+
+// RankedWord is a word found on a board, with its frequency from the words
+// file.
+type RankedWord struct {
+	// Word is the word found.
+	Word string
+	// Frequency is the frequency of the word, as returned by Frequency.
+	Frequency int
+	// Rank is the position of the word among all the words in the dictionary
+	// when ordered from most to least frequent, where the most frequent word
+	// has rank 1. Words with the same frequency have the same rank. Rank is
+	// zero if the word has no frequency.
+	Rank int
+}
+
+// SolveRanked generates all solutions for the given Boggle grid, the same as
+// Solve, and returns each word with its frequency and its frequency rank in
+// the dictionary, using the frequencies from the words file given by
+// WithFrequencyColumn. This tells common words from rare ones, such as to
+// show the difficulty of each word found. If the Solver has no frequencies,
+// then the frequency and rank of every word is zero.
+//
+// Ranking looks at the frequency of every word in the dictionary, so takes
+// time in proportion to the size of the dictionary.
+func (s Solver) SolveRanked(grid string) ([]RankedWord, error) {
+	words, err := s.Solve(grid)
+	if err != nil {
+		return nil, err
+	}
+	ranked := make([]RankedWord, len(words))
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	// Frequencies of all words, from most to least frequent.
+	var freqs []int
+	if len(s.freq) != 0 {
+		freqs = make([]int, 0, len(s.freq))
+		for _, n := range s.freq {
+			if n != 0 {
+				freqs = append(freqs, n)
+			}
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(freqs)))
+	}
+	for i, w := range words {
+		n := s.freq[s.foldCase(w)]
+		ranked[i] = RankedWord{
+			Word:      w,
+			Frequency: n,
+		}
+		if n != 0 {
+			// Rank is one more than the number of more frequent words.
+			ranked[i].Rank = 1 + sort.Search(len(freqs), func(j int) bool { return freqs[j] <= n })
+		}
+	}
+	return ranked, nil
+}
+
+// filterWords returns the words for which keep returns true, reusing the given
+// slice.
+func filterWords(words []string, keep func(word string) bool) []string {
+	kept := words[:0]
+	for _, w := range words {
+		if keep(w) {
+			kept = append(kept, w)
+		}
+	}
+	return kept
+}
+
+// ValidateWords checks a list of words claimed to be solutions for the given
+// Boggle grid, and returns the words that are not solutions, in the order
+// given. A word is a solution if it is in the Solver's dictionary and can be
+// traced on the board. Words are matched without regard to case.
+//
+// This confirms that a list of solutions, such as one saved from an earlier
+// version of a program or given by a player, has no words that are not on the
+// board. Compare the lengths of the list and of the solutions from Solve to
+// also check that no words are missing.
+func (s Solver) ValidateWords(grid string, words []string) ([]string, error) {
+	found, err := s.Solve(grid)
+	if err != nil {
+		return nil, err
+	}
+	valid := make(map[string]struct{}, len(found))
+	for _, w := range found {
+		valid[strings.ToLower(w)] = struct{}{}
+	}
+	var invalid []string
+	for _, w := range words {
+		if _, ok := valid[strings.ToLower(w)]; !ok {
+			invalid = append(invalid, w)
+		}
+	}
+	return invalid, nil
+}
+
+// FindableWords returns the words of a reference list, such as a list of
+// spelling words, that are solutions for the given Boggle grid. A word is a
+// solution if it is in the Solver's dictionary and can be traced on the board.
+// The number of words returned, compared to the length of the list, tells how
+// much of the list the board covers, for choosing boards that practice the
+// words of the list.
+//
+// The words are returned in the order of the reference list, as given in the
+// list. Words are matched without regard to case, and a word given more than
+// once is returned once.
+func (s Solver) FindableWords(grid string, reference []string) ([]string, error) {
+	words, err := s.Solve(grid)
+	if err != nil {
+		return nil, err
+	}
+	solutions := NewWordSet(words)
+	var findable []string
+	for _, w := range reference {
+		if solutions.Contains(w) {
+			findable = append(findable, w)
+			delete(solutions, strings.ToLower(w))
+		}
+	}
+	return findable, nil
+}
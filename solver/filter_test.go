@@ -0,0 +1,321 @@
+package solver
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestSolveExcluding(t *testing.T) {
+	wordsPath := writeWords(t, "queen", "quit", "tin", "net", "ten")
+	s, err := New(4, 2, wordsPath, WithWordCase(UpperCase))
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qeentinx"
+	words, err := s.SolveExcluding(grid, []string{"queen", "Tin", "dog"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"NET", "QUIT", "TEN"}) {
+		t.Fatal("wrong solutions:", words)
+	}
+
+	words, err = s.SolveExcluding(grid, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(words) != 5 {
+		t.Fatal("expected all solutions:", words)
+	}
+}
+
+func TestSolveNotIn(t *testing.T) {
+	wordsPath := writeWords(t, "queen", "quit", "tin", "net", "ten", "nit", "quin")
+	s, err := New(4, 2, wordsPath, WithWordCase(TitleCase))
+	if err != nil {
+		t.Fatal(err)
+	}
+	common, err := LoadWordSet(writeWords(t, "QUEEN", "Tin", "ten", "dog", "the"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(common) != 5 || !common.Contains("Queen") || common.Contains("quit") {
+		t.Fatal("wrong word set:", common)
+	}
+	grid := "qeentinx"
+	words, err := s.SolveNotIn(grid, common)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"Net", "Nit", "Quin", "Quit"}) {
+		t.Fatal("wrong solutions:", words)
+	}
+
+	words, err = s.SolveNotIn(grid, NewWordSet([]string{"nit", "net", "quin", "quit"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"Queen", "Ten", "Tin"}) {
+		t.Fatal("wrong solutions:", words)
+	}
+
+	if _, err = LoadWordSet("_not_here_"); err == nil {
+		t.Fatal("expected error loading missing file")
+	}
+}
+
+func TestSolveMatching(t *testing.T) {
+	wordsPath := writeWords(t, "sing", "ring", "grin", "sign", "great", "quit")
+	s, err := New(3, 4, wordsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// +---+---+---+
+	// | S | I | N |
+	// +---+---+---+
+	// | R | G | X |
+	// +---+---+---+
+	// | E | A | T |
+	// +---+---+---+
+	// | X | Qu| I |
+	// +---+---+---+
+	grid := "sinrgxeatxqi"
+	words, err := s.SolveMatching(grid, regexp.MustCompile("ing$"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"ring", "sing"}) {
+		t.Fatal("wrong words ending in ing:", words)
+	}
+
+	twoVowels := regexp.MustCompile("^[^aeiou]*[aeiou][^aeiou]*[aeiou][^aeiou]*$")
+	words, err = s.SolveMatching(grid, twoVowels)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"great", "quit"}) {
+		t.Fatal("wrong words with two vowels:", words)
+	}
+
+	words, err = s.SolveMatching(grid, regexp.MustCompile("^qu"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"quit"}) {
+		t.Fatal("wrong words beginning with qu:", words)
+	}
+}
+
+func TestValidateWords(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflvctor"
+	words, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid, err := s.ValidateWords(grid, words)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invalid) != 0 {
+		t.Fatal("expected all words valid, got invalid:", invalid)
+	}
+
+	// "quartz" is in the dictionary but not on the board, and "darte" can be
+	// traced but is not in the dictionary.
+	claimed := []string{"QUARTE", "quartz", "heart", "darte"}
+	invalid, err = s.ValidateWords(grid, claimed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(invalid, []string{"quartz", "darte"}) {
+		t.Fatal("wrong invalid words:", invalid)
+	}
+
+	if _, err = s.ValidateWords("qadf", claimed); !errors.Is(err, ErrGridTooShort) {
+		t.Fatal("expected ErrGridTooShort, got", err)
+	}
+}
+
+func TestStudyOrder(t *testing.T) {
+	freq := map[string]int{
+		"the":   1000,
+		"heart": 50,
+		"earth": 50,
+		"queen": 20,
+		"rathe": 0,
+	}
+	words := []string{"rathe", "Queen", "zax", "earth", "THE", "heart", "qua"}
+	ordered := StudyOrder(words, freq)
+	expect := []string{"THE", "earth", "heart", "Queen", "rathe", "qua", "zax"}
+	if !reflect.DeepEqual(ordered, expect) {
+		t.Fatal("wrong study order:", ordered)
+	}
+	if words[0] != "rathe" {
+		t.Fatal("input modified")
+	}
+	if len(StudyOrder(nil, freq)) != 0 {
+		t.Fatal("expected no words")
+	}
+}
+
+func TestFrequencyColumn(t *testing.T) {
+	wordsPath := writeWords(t,
+		"cat 5000",
+		"cats\t1200",
+		"tea 800 extra",
+		"eat",
+		"seat x",
+		"Cast 10",
+		"east 40")
+	s, err := New(3, 3, wordsPath, WithFrequencyColumn(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.WordCount() != 6 {
+		t.Fatal("wrong word count:", s.WordCount())
+	}
+	for word, expect := range map[string]int{
+		"cat":  5000,
+		"CATS": 1200,
+		"tea":  800,
+		"eat":  0,
+		"seat": 0,
+		"east": 40,
+		"cast": 0,
+		"dog":  0,
+	} {
+		if n := s.Frequency(word); n != expect {
+			t.Errorf("expected frequency %d for %q, got %d", expect, word, n)
+		}
+	}
+
+	// +---+---+---+
+	// | C | A | T |
+	// +---+---+---+
+	// | X | E | S |
+	// +---+---+---+
+	// | X | X | X |
+	// +---+---+---+
+	words, err := s.SolveRarest("catxesxxx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []string{"eat", "seat", "east", "tea", "cats", "cat"}
+	if !reflect.DeepEqual(words, expect) {
+		t.Fatalf("expected %v, got %v", expect, words)
+	}
+
+	// Without frequencies, words are alphabetical.
+	s, err = New(3, 3, writeWords(t, "cat", "tea", "eat"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	words, err = s.SolveRarest("catxesxxx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"cat", "eat", "tea"}) {
+		t.Fatal("wrong words without frequencies:", words)
+	}
+}
+
+func TestSolveRanked(t *testing.T) {
+	wordsPath := writeWords(t,
+		"cat 5000",
+		"cats 1200",
+		"tea 800",
+		"eat",
+		"dog 1200",
+		"seat 9000")
+	s, err := New(3, 3, wordsPath, WithFrequencyColumn(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// +---+---+---+
+	// | C | A | T |
+	// +---+---+---+
+	// | X | E | X |
+	// +---+---+---+
+	// | X | X | X |
+	// +---+---+---+
+	ranked, err := s.SolveRanked("catxexxxx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []RankedWord{
+		{Word: "cat", Frequency: 5000, Rank: 2},
+		{Word: "eat", Frequency: 0, Rank: 0},
+		{Word: "tea", Frequency: 800, Rank: 5},
+	}
+	if !reflect.DeepEqual(ranked, expect) {
+		t.Fatalf("expected %v, got %v", expect, ranked)
+	}
+
+	// Words with the same frequency have the same rank.
+	ranked, err = s.SolveRanked("catxsxxxx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect = []RankedWord{
+		{Word: "cat", Frequency: 5000, Rank: 2},
+		{Word: "cats", Frequency: 1200, Rank: 3},
+	}
+	if !reflect.DeepEqual(ranked, expect) {
+		t.Fatalf("expected %v, got %v", expect, ranked)
+	}
+
+	// Without frequencies, frequency and rank are zero.
+	s, err = New(3, 3, writeWords(t, "cat", "tea", "eat"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ranked, err = s.SolveRanked("catxexxxx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rw := range ranked {
+		if rw.Frequency != 0 || rw.Rank != 0 {
+			t.Fatal("expected zero frequency and rank:", rw)
+		}
+	}
+	if len(ranked) != 3 {
+		t.Fatal("wrong number of words:", len(ranked))
+	}
+}
+
+func TestFindableWords(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflvctor"
+	// "quartz" is in the dictionary but not on the board, and "darte" can be
+	// traced but is not in the dictionary.
+	reference := []string{"heart", "quartz", "QUARTE", "darte", "Heart", "victor"}
+	findable, err := s.FindableWords(grid, reference)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []string{"heart", "QUARTE"}
+	if !reflect.DeepEqual(findable, expect) {
+		t.Fatalf("expected %v, got %v", expect, findable)
+	}
+
+	findable, err = s.FindableWords(grid, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findable) != 0 {
+		t.Fatal("expected no words, got", findable)
+	}
+
+	if _, err = s.FindableWords("qadf", reference); !errors.Is(err, ErrGridTooShort) {
+		t.Fatal("expected ErrGridTooShort, got", err)
+	}
+}
@@ -0,0 +1,84 @@
+package solver
+
+import (
+	"bytes"
+	"strings"
+)
+
+// DefaultLineWidth is the line width used by FormatWords when the line width
+// is not given.
+const DefaultLineWidth = 80
+
+// ColumnFormat sets how FormatWords arranges words into columns. The zero
+// value fits as many columns as will fill a line of DefaultLineWidth, each as
+// wide as the longest word.
+type ColumnFormat struct {
+	// Columns is the number of columns. If zero, then as many columns are
+	// used as fit in LineWidth.
+	Columns int
+	// Width is the width of each column, not counting the separator. If zero,
+	// the width of the longest word is used. A word longer than the width is
+	// not cut short, and pushes the rest of its row to the right.
+	Width int
+	// LineWidth is the width of a line, used to fit columns when Columns is
+	// zero. If zero, DefaultLineWidth is used.
+	LineWidth int
+	// Separator is written between columns. If empty, two spaces are used.
+	Separator string
+	// AlignRight aligns words to the right side of their columns instead of
+	// the left.
+	AlignRight bool
+}
+
+// FormatWords returns the words arranged in rows of columns, for printing a
+// list of solutions. The words are written in the order given, from left to
+// right across each row. Each row ends with a newline and has no trailing
+// spaces. At least one column is used, even if one column is wider than the
+// line.
+func FormatWords(words []string, format ColumnFormat) string {
+	if len(words) == 0 {
+		return ""
+	}
+	sep := format.Separator
+	if sep == "" {
+		sep = "  "
+	}
+	width := format.Width
+	if width <= 0 {
+		for _, w := range words {
+			width = max(width, len(w))
+		}
+	}
+	cols := format.Columns
+	if cols <= 0 {
+		lineWidth := format.LineWidth
+		if lineWidth <= 0 {
+			lineWidth = DefaultLineWidth
+		}
+		cols = max(1, (lineWidth+len(sep))/(width+len(sep)))
+	}
+
+	var sb strings.Builder
+	var line []byte
+	for i, w := range words {
+		if i%cols != 0 {
+			line = append(line, sep...)
+		}
+		pad := width - len(w)
+		if format.AlignRight {
+			for ; pad > 0; pad-- {
+				line = append(line, ' ')
+			}
+		}
+		line = append(line, w...)
+		for ; pad > 0; pad-- {
+			line = append(line, ' ')
+		}
+		if i%cols == cols-1 || i == len(words)-1 {
+			sb.Write(bytes.TrimRight(line, " "))
+			sb.WriteByte('\n')
+			line = line[:0]
+		}
+	}
+	return sb.String()
+}
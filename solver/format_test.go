@@ -0,0 +1,57 @@
+package solver
+
+import (
+	"testing"
+)
+
+func TestFormatWords(t *testing.T) {
+	words := []string{"cat", "tea", "quit", "at", "eat"}
+	for _, tc := range []struct {
+		name   string
+		format ColumnFormat
+		expect string
+	}{
+		{
+			name:   "fixed",
+			format: ColumnFormat{Columns: 2, Width: 5},
+			expect: "cat    tea\nquit   at\neat\n",
+		},
+		{
+			name:   "separator",
+			format: ColumnFormat{Columns: 3, Width: 4, Separator: " | "},
+			expect: "cat  | tea  | quit\nat   | eat\n",
+		},
+		{
+			name:   "right",
+			format: ColumnFormat{Columns: 3, AlignRight: true},
+			expect: " cat   tea  quit\n  at   eat\n",
+		},
+		{
+			name:   "fit",
+			format: ColumnFormat{LineWidth: 16},
+			expect: "cat   tea   quit\nat    eat\n",
+		},
+		{
+			name:   "fit default",
+			format: ColumnFormat{},
+			expect: "cat   tea   quit  at    eat\n",
+		},
+		{
+			name:   "narrow line",
+			format: ColumnFormat{LineWidth: 2},
+			expect: "cat\ntea\nquit\nat\neat\n",
+		},
+		{
+			name:   "long word",
+			format: ColumnFormat{Columns: 2, Width: 3, Separator: " "},
+			expect: "cat tea\nquit at\neat\n",
+		},
+	} {
+		if out := FormatWords(words, tc.format); out != tc.expect {
+			t.Errorf("%s: expected:\n%q\ngot:\n%q", tc.name, tc.expect, out)
+		}
+	}
+	if out := FormatWords(nil, ColumnFormat{}); out != "" {
+		t.Errorf("expected empty output, got %q", out)
+	}
+}
@@ -0,0 +1,549 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FillPlaceholder marks a cell in a partial grid that FillGrid fills with a
+// random letter.
+const FillPlaceholder = '.'
+
+// letterWeights are the relative frequencies of the letters a-z in English
+// text, used to generate random letters for a grid.
+var letterWeights = [26]int{
+	82, 15, 28, 43, 127, 22, 20, 61, 70, 2, 8, 40, 24,
+	67, 75, 19, 1, 60, 63, 91, 28, 10, 24, 2, 20, 1,
+}
+
+var letterWeightTotal = func() int {
+	var total int
+	for _, w := range letterWeights {
+		total += w
+	}
+	return total
+}()
+
+// randomLetter returns a random letter chosen according to letterWeights.
+func randomLetter(rng *rand.Rand) byte {
+	n := rng.Intn(letterWeightTotal)
+	for i, w := range letterWeights {
+		if n < w {
+			return byte('a' + i)
+		}
+		n -= w
+	}
+	return 'e'
+}
+
+// FillGrid completes a partial grid by replacing each FillPlaceholder cell
+// with a random letter, weighted by the frequency of letters in English text.
+// Letters in the partial grid are left intact. The partial grid must have size
+// cells, each of which is either a letter or a placeholder, counting each
+// placeholder as one cell. If it does not, then an error wrapping both
+// ErrInvalidDimensions and ErrGridTooShort or ErrGridTooLong is returned, so
+// that a mis-sized grid is caught here and not later by Solve.
+//
+// If rng is nil, then a generator seeded with the current time is used. The
+// completed grid is returned in lower case, ready for Solve.
+func FillGrid(partial string, size int, rng *rand.Rand) (string, error) {
+	if size < 1 {
+		return "", fmt.Errorf("%w: size %d", ErrInvalidDimensions, size)
+	}
+	if err := checkGridSize(partial, size); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidDimensions, err)
+	}
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
+	}
+
+	grid := []byte(strings.ToLower(partial))
+	for i, c := range grid {
+		switch {
+		case c == FillPlaceholder:
+			grid[i] = randomLetter(rng)
+		case c < 'a' || c > 'z':
+			return "", fmt.Errorf("%w: %q at square %d", ErrInvalidCharacter, partial[i], i)
+		}
+	}
+	return string(grid), nil
+}
+
+// PlaceWords finds a small square board on which every one of the given words
+// can be traced, and returns the board's grid and its width. Words are placed
+// so that they share squares where their letters allow it, and the remaining
+// squares are filled with random letters.
+//
+// Finding the smallest such board is a hard problem, so this uses a greedy
+// heuristic: starting from the smallest board that can hold the longest word,
+// up to maxAttempts random placements are tried for each board size before
+// trying the next larger size. A board large enough to hold all the words
+// without sharing any squares always succeeds. The larger maxAttempts is, the
+// more likely a smaller board is found, and the longer it takes.
+//
+// If rng is nil, then a generator seeded with the current time is used. The
+// returned grid can be given to a Solver with the returned width as both of
+// its dimensions, and the words are found if they are in its dictionary.
+func PlaceWords(words []string, maxAttempts int, rng *rand.Rand) (string, int, error) {
+	if len(words) == 0 {
+		return "", 0, errors.New("no words to place")
+	}
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
+	}
+
+	// Place words as the tiles that spell them, longest first.
+	tiles := make([]string, len(words))
+	var total int
+	for i, w := range words {
+		t := NormalizeWord(w)
+		if t == "" {
+			return "", 0, errors.New("cannot place empty word")
+		}
+		for j := 0; j < len(t); j++ {
+			if t[j] < 'a' || t[j] > 'z' {
+				return "", 0, fmt.Errorf("%w: %q in word %q", ErrInvalidCharacter, t[j], w)
+			}
+		}
+		tiles[i] = t
+		total += len(t)
+	}
+	sort.Slice(tiles, func(i, j int) bool { return len(tiles[i]) > len(tiles[j]) })
+
+	maxN := int(math.Ceil(math.Sqrt(float64(total))))
+	for n := int(math.Ceil(math.Sqrt(float64(len(tiles[0]))))); n < maxN; n++ {
+		adjTable := adjacencyTable(n, n, nil)
+		for attempt := 0; attempt < maxAttempts; attempt++ {
+			cells := make([]byte, n*n)
+			placed := true
+			for _, t := range tiles {
+				if !placeTiles(cells, adjTable, t, rng) {
+					placed = false
+					break
+				}
+			}
+			if placed {
+				return fillCells(cells, rng), n, nil
+			}
+		}
+	}
+
+	// Lay out the words end to end, along a path that snakes back and forth
+	// across the rows of the board.
+	cells := make([]byte, maxN*maxN)
+	var i int
+	for _, t := range tiles {
+		for j := 0; j < len(t); j++ {
+			y := i / maxN
+			x := i % maxN
+			if y%2 == 1 {
+				x = maxN - 1 - x
+			}
+			cells[y*maxN+x] = t[j]
+			i++
+		}
+	}
+	return fillCells(cells, rng), maxN, nil
+}
+
+// placeTiles tries to place the tiles on a path through the cells, where each
+// cell is either empty (0) or already has the needed letter. Cells that have
+// the needed letter are tried first, so that words share squares. Returns
+// false if the tiles could not be placed.
+func placeTiles(cells []byte, adjTable [][]int, tiles string, rng *rand.Rand) bool {
+	path := make([]int, 0, len(tiles))
+	var place func(sq int) bool
+	place = func(sq int) bool {
+		c := cells[sq]
+		if c != 0 && c != tiles[len(path)] {
+			return false
+		}
+		for _, p := range path {
+			if p == sq {
+				return false
+			}
+		}
+		path = append(path, sq)
+		if len(path) == len(tiles) {
+			return true
+		}
+		next := append([]int(nil), adjTable[sq]...)
+		rng.Shuffle(len(next), func(i, j int) { next[i], next[j] = next[j], next[i] })
+		sortSharedFirst(cells, next, tiles[len(path)])
+		for _, sq = range next {
+			if place(sq) {
+				return true
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+
+	starts := rng.Perm(len(cells))
+	sortSharedFirst(cells, starts, tiles[0])
+	for _, sq := range starts {
+		if place(sq) {
+			for i, p := range path {
+				cells[p] = tiles[i]
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// sortSharedFirst moves the squares whose cell has the given letter to the
+// front of squares.
+func sortSharedFirst(cells []byte, squares []int, letter byte) {
+	sort.SliceStable(squares, func(i, j int) bool {
+		return cells[squares[i]] == letter && cells[squares[j]] != letter
+	})
+}
+
+// fillCells returns a grid from the cells, with any empty cells filled with
+// random letters.
+func fillCells(cells []byte, rng *rand.Rand) string {
+	for i, c := range cells {
+		if c == 0 {
+			cells[i] = randomLetter(rng)
+		}
+	}
+	return string(cells)
+}
+
+// GenerateBoard generates random grids until it finds one with a number of
+// solutions within the range minWords to maxWords, inclusive, and returns the
+// grid and its number of solutions. This gives boards of a controlled
+// difficulty, such as a "medium" board.
+//
+// Letters are chosen using weights that blend letter frequencies in English
+// text, with extra weight for vowels, with an even distribution of letters.
+// The blend is adjusted after each rejected grid: toward common letters when
+// a grid has too few solutions, and toward an even distribution when a grid
+// has too many.
+//
+// Generation continues until a grid is found or the context is canceled. If
+// rng is nil, then a generator seeded with the current time is used.
+func (s Solver) GenerateBoard(ctx context.Context, minWords, maxWords int, rng *rand.Rand) (string, int, error) {
+	if minWords < 0 || maxWords < minWords {
+		return "", 0, errors.New("invalid range of solution counts")
+	}
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
+	}
+
+	grid := make([]byte, s.BoardSize())
+	bias := 0.5
+	for {
+		if err := ctx.Err(); err != nil {
+			return "", 0, err
+		}
+		weights := biasedWeights(bias)
+		for i := range grid {
+			grid[i] = weightedLetter(weights, rng)
+		}
+		words, err := s.Solve(string(grid))
+		if err != nil {
+			return "", 0, err
+		}
+		n := len(words)
+		switch {
+		case n < minWords:
+			bias = math.Min(1, bias+0.05)
+		case n > maxWords:
+			bias = math.Max(0, bias-0.05)
+		default:
+			return string(grid), n, nil
+		}
+	}
+}
+
+// GenerateLengths searches for a grid whose solutions have a number of words
+// of each length close to the target, such as 10 words of three letters and
+// 5 words of five letters, for sets of puzzles with a curated mix of words.
+// The target maps a word length, in letters, to the number of words of that
+// length. Words of a length that is not in the target are wanted zero times.
+//
+// How close a grid is to the target is the sum, over all word lengths, of the
+// difference between the number of words found and the number wanted. The
+// search begins with a random grid, and for each attempt changes one random
+// square to a random letter, keeping the change unless it moves the grid
+// further from the target. The closest grid found is returned with the number
+// of its words of each length, as counted by LengthQuality. The search stops
+// early if the grid matches the target exactly.
+//
+// If the context is canceled, then the closest grid found so far is returned
+// with the context's error. If rng is nil, then a generator seeded with the
+// current time is used.
+func (s Solver) GenerateLengths(ctx context.Context, target map[int]int, maxAttempts int, rng *rand.Rand) (string, map[int]int, error) {
+	for length, n := range target {
+		if length < 1 || n < 0 {
+			return "", nil, fmt.Errorf("invalid target of %d words of length %d", n, length)
+		}
+	}
+	if maxAttempts < 1 {
+		return "", nil, errors.New("max attempts must be at least 1")
+	}
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
+	}
+
+	// solve returns the number of words of each length found in the grid, and
+	// the distance of those counts from the target.
+	solve := func(grid []byte) (map[int]int, int, error) {
+		q, err := s.LengthQuality(string(grid))
+		if err != nil {
+			return nil, 0, err
+		}
+		counts := q.Counts
+		var dist int
+		for length, n := range counts {
+			dist += max(n-target[length], target[length]-n)
+		}
+		for length, n := range target {
+			if _, ok := counts[length]; !ok {
+				dist += n
+			}
+		}
+		return counts, dist, nil
+	}
+
+	weights := biasedWeights(0.5)
+	grid := make([]byte, s.BoardSize())
+	for i := range grid {
+		grid[i] = weightedLetter(weights, rng)
+	}
+	counts, dist, err := solve(grid)
+	if err != nil {
+		return "", nil, err
+	}
+	for attempt := 1; attempt < maxAttempts && dist != 0; attempt++ {
+		if err = ctx.Err(); err != nil {
+			return string(grid), counts, err
+		}
+		sq := rng.Intn(len(grid))
+		prev := grid[sq]
+		grid[sq] = weightedLetter(weights, rng)
+		c, d, err := solve(grid)
+		if err != nil {
+			return "", nil, err
+		}
+		if d > dist {
+			grid[sq] = prev
+			continue
+		}
+		counts, dist = c, d
+	}
+	return string(grid), counts, nil
+}
+
+// biasedWeights returns letter weights that blend an even distribution of
+// letters, when bias is 0, with the English letter frequencies plus extra
+// weight for vowels, when bias is 1.
+func biasedWeights(bias float64) [26]float64 {
+	var weights [26]float64
+	for i, w := range letterWeights {
+		freq := float64(w) / float64(letterWeightTotal)
+		switch byte('a' + i) {
+		case 'a', 'e', 'i', 'o', 'u':
+			freq *= 1.5
+		}
+		weights[i] = (1-bias)/26 + bias*freq
+	}
+	return weights
+}
+
+// weightedLetter returns a random letter chosen according to the weights.
+func weightedLetter(weights [26]float64, rng *rand.Rand) byte {
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	n := rng.Float64() * total
+	for i, w := range weights {
+		if n < w {
+			return byte('a' + i)
+		}
+		n -= w
+	}
+	return 'e'
+}
+
+// GenerateSingleWord generates a grid in which the only word found is the
+// target word, for puzzles where players search for a single hidden word.
+//
+// The target is placed on a random path through the board, and the other
+// squares are filled with random letters. After each solve, a square of the
+// path of every other word found is changed to a new random letter, until only
+// the target remains. If another word can be traced using only the target's
+// squares, then the target is placed on a new path. Each solve counts as one
+// attempt, and an error is returned if no grid is found within maxAttempts
+// attempts or before the context is canceled. No grid is ever found for a
+// target that contains other words, such as "planet", which contains "plane".
+// If the Solver was created using WithNodeLimit and a solve reaches the limit,
+// then the grid cannot be checked for other words, and an error wrapping
+// ErrNodeLimit is returned.
+//
+// If rng is nil, then a generator seeded with the current time is used.
+func (s Solver) GenerateSingleWord(ctx context.Context, target string, maxAttempts int, rng *rand.Rand) (string, error) {
+	tiles := NormalizeWord(target)
+	if tiles == "" {
+		return "", errors.New("no target word")
+	}
+	for i := 0; i < len(tiles); i++ {
+		if tiles[i] < 'a' || tiles[i] > 'z' {
+			return "", fmt.Errorf("%w: %q in word %q", ErrInvalidCharacter, tiles[i], target)
+		}
+	}
+	if s.dict == nil {
+		return "", ErrNoDictionary
+	}
+	word, ok := s.lookup(tiles)
+	if !ok {
+		return "", fmt.Errorf("word %q not in dictionary", target)
+	}
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
+	}
+
+	weights := biasedWeights(0)
+	cells := make([]byte, s.BoardSize())
+	fixed := make([]bool, len(cells))
+	var placed bool
+	sc := &Scratch{}
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		if !placed {
+			clear(cells)
+			for sq := range cells {
+				if s.mask != nil && !s.mask[sq] {
+					cells[sq] = FillPlaceholder // not part of any path
+				}
+			}
+			if !placeTiles(cells, s.adj, tiles, rng) {
+				return "", errors.New("target word does not fit on board")
+			}
+			for sq, c := range cells {
+				fixed[sq] = c != 0
+				if c == 0 {
+					cells[sq] = weightedLetter(weights, rng)
+				}
+			}
+			placed = true
+		}
+
+		board := string(cells)
+		sc.resetNodes()
+		var others int
+		found := func(w string, path []int) bool {
+			if w == word {
+				return true
+			}
+			others++
+			var free []int
+			for _, sq := range path {
+				if !fixed[sq] {
+					free = append(free, sq)
+				}
+			}
+			if len(free) == 0 {
+				placed = false // traced using only the target's squares
+				return false
+			}
+			sq := free[rng.Intn(len(free))]
+			cells[sq] = weightedLetter(weights, rng)
+			return true
+		}
+		for initSq := 0; initSq < len(board); initSq++ {
+			if !s.search(sc, board, initSq, found) {
+				break
+			}
+		}
+		if err := sc.nodeErr(); err != nil {
+			return "", fmt.Errorf("cannot check grid for other words: %w", err)
+		}
+		if others == 0 {
+			return board, nil
+		}
+	}
+	return "", fmt.Errorf("no grid with only the word %q found in %d attempts", target, maxAttempts)
+}
+
+// ImproveByOneSwap finds the single change of one square's letter that gives
+// the grid the most words. Each square is replaced by each of the other 25
+// letters, and the words in each resulting grid are counted. The grid with the
+// most words is returned along with its count. If more than one grid has the
+// most words, the one changing the lowest square, to the earliest letter in
+// the alphabet, is returned. If no change gives more words than the grid
+// already has, then the grid is returned unchanged along with its count.
+//
+// This solves 25 grids for each square of the board, so is only practical for
+// small boards. Squares absent from a masked board, and the blank squares
+// padding a short grid, are not changed.
+func (s Solver) ImproveByOneSwap(grid string) (string, int, error) {
+	bestCount, err := s.SolveCount(grid)
+	if err != nil {
+		return "", 0, err
+	}
+	best := grid
+	cells := []byte(grid)
+	for sq, orig := range cells {
+		if s.mask != nil && !s.mask[sq] {
+			continue
+		}
+		for c := byte('a'); c <= 'z'; c++ {
+			if c == orig|0x20 {
+				continue
+			}
+			cells[sq] = c
+			n, err := s.SolveCount(string(cells))
+			if err != nil {
+				return "", 0, err
+			}
+			if n > bestCount {
+				best, bestCount = string(cells), n
+			}
+		}
+		cells[sq] = orig
+	}
+	return best, bestCount, nil
+}
+
+// SampleSolutions returns n distinct solutions for the given Boggle grid,
+// chosen at random using rng, such as the words to find in a daily puzzle.
+// The same words are chosen for the same grid, dictionary, and state of rng,
+// so a puzzle made from a fixed seed is the same everywhere. The words are
+// returned in the order chosen. If n is at least the number of solutions,
+// then all solutions are returned, in random order.
+//
+// If rng is nil, then a generator seeded with the current time is used.
+func (s Solver) SampleSolutions(grid string, n int, rng *rand.Rand) ([]string, error) {
+	words, err := s.Solve(grid)
+	if err != nil {
+		return nil, err
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
+	}
+	// Sort so that the choice does not depend on the order of results.
+	if s.cfg.unsorted {
+		sort.Strings(words)
+	}
+	n = min(n, len(words))
+	for i := 0; i < n; i++ {
+		j := i + rng.Intn(len(words)-i)
+		words[i], words[j] = words[j], words[i]
+	}
+	return words[:n], nil
+}
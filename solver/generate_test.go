@@ -0,0 +1,349 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFillGrid(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	partial := "Q..t....r..e...."
+	grid, err := FillGrid(partial, 16, rng)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(grid) != 16 {
+		t.Fatal("wrong grid length:", len(grid))
+	}
+	for i, c := range []byte(grid) {
+		if c < 'a' || c > 'z' {
+			t.Fatalf("cell %d not filled with letter: %q", i, c)
+		}
+		if partial[i] != FillPlaceholder && c != partial[i]|0x20 {
+			t.Fatalf("fixed cell %d changed from %q to %q", i, partial[i], c)
+		}
+	}
+
+	again, err := FillGrid(partial, 16, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != grid {
+		t.Fatal("same seed produced different grid")
+	}
+
+	s, err := New(4, 4, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Solve(grid); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = FillGrid("ab..", 5, rng); !errors.Is(err, ErrGridTooShort) || !errors.Is(err, ErrInvalidDimensions) {
+		t.Fatal("failed to catch short partial grid")
+	}
+	if _, err = FillGrid("ab...", 4, rng); !errors.Is(err, ErrGridTooLong) || !errors.Is(err, ErrInvalidDimensions) {
+		t.Fatal("failed to catch long partial grid")
+	}
+	if _, err = FillGrid("a1..", 4, rng); !errors.Is(err, ErrInvalidCharacter) {
+		t.Fatal("failed to catch invalid character")
+	}
+	if _, err = FillGrid("", 0, rng); !errors.Is(err, ErrInvalidDimensions) {
+		t.Fatal("failed to catch invalid size")
+	}
+}
+
+func TestPlaceWords(t *testing.T) {
+	targets := []string{"queen", "quiet", "tea", "eat", "net", "tent"}
+	grid, n, err := PlaceWords(targets, 100, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(grid) != n*n {
+		t.Fatalf("grid length %d does not match %dx%d board", len(grid), n, n)
+	}
+	// Board should be no larger than needed to hold all words without sharing.
+	if n > 5 {
+		t.Fatal("board larger than needed:", n)
+	}
+
+	s, err := New(n, n, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	words, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, target := range targets {
+		if !sortedContains(words, target) {
+			t.Errorf("word %q not found in grid %q", target, grid)
+		}
+	}
+
+	// With no attempts, words are placed without sharing squares.
+	grid, n, err = PlaceWords(targets, 0, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 5 {
+		t.Fatal("expected 5x5 board, got", n)
+	}
+	s, err = New(n, n, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	words, err = s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, target := range targets {
+		if !sortedContains(words, target) {
+			t.Errorf("word %q not found in grid %q", target, grid)
+		}
+	}
+
+	if _, _, err = PlaceWords(nil, 10, nil); err == nil {
+		t.Fatal("failed to catch no words")
+	}
+	if _, _, err = PlaceWords([]string{"it's"}, 10, nil); !errors.Is(err, ErrInvalidCharacter) {
+		t.Fatal("expected ErrInvalidCharacter, got", err)
+	}
+}
+
+func sortedContains(words []string, word string) bool {
+	i := sort.SearchStrings(words, word)
+	return i < len(words) && words[i] == word
+}
+
+func TestGenerateBoard(t *testing.T) {
+	s, err := New(4, 4, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	grid, n, err := s.GenerateBoard(ctx, 20, 400, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n < 20 || n > 400 {
+		t.Fatal("solution count out of range:", n)
+	}
+	words, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(words) != n {
+		t.Fatal("wrong solution count for grid")
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if _, _, err = s.GenerateBoard(ctx, 20, 400, nil); !errors.Is(err, context.Canceled) {
+		t.Fatal("expected context.Canceled, got", err)
+	}
+	if _, _, err = s.GenerateBoard(context.Background(), 20, 10, nil); err == nil {
+		t.Fatal("failed to catch invalid range")
+	}
+}
+
+func TestGenerateSingleWord(t *testing.T) {
+	s, err := New(4, 4, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	for _, target := range []string{"rhythm", "Quiz", "fjord"} {
+		grid, err := s.GenerateSingleWord(ctx, target, 1000, rand.New(rand.NewSource(1)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		words, err := s.Solve(grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(words) != 1 || words[0] != strings.ToLower(target) {
+			t.Fatalf("expected only %q in grid %q, found %v", target, grid, words)
+		}
+	}
+
+	if _, err = s.GenerateSingleWord(ctx, "qwxz", 10, nil); err == nil {
+		t.Fatal("failed to catch word not in dictionary")
+	}
+	if _, err = s.GenerateSingleWord(ctx, "abcdefghijklmnopq", 10, nil); err == nil {
+		t.Fatal("failed to catch word too long for board")
+	}
+	if _, err = s.GenerateSingleWord(ctx, "fjord", 0, nil); err == nil {
+		t.Fatal("expected error with no attempts")
+	}
+	// The word "plane" is always found along with "planet".
+	if _, err = s.GenerateSingleWord(ctx, "planet", 100, nil); err == nil {
+		t.Fatal("expected error for word containing other words")
+	}
+
+	// A grid that is not completely searched is not returned.
+	limited, err := New(4, 4, writeWords(t, "fjord", "for"), WithNodeLimit(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = limited.GenerateSingleWord(ctx, "fjord", 100, nil); !errors.Is(err, ErrNodeLimit) {
+		t.Fatal("expected ErrNodeLimit, got", err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if _, err = s.GenerateSingleWord(ctx, "planet", 10, nil); !errors.Is(err, context.Canceled) {
+		t.Fatal("expected context.Canceled, got", err)
+	}
+}
+
+func TestImproveByOneSwap(t *testing.T) {
+	s, err := New(2, 2, writeWords(t, "cat", "act", "tac", "cab"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid, count, err := s.ImproveByOneSwap("caxt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if grid != "cabt" || count != 4 {
+		t.Fatalf("expected cabt with 4 words, got %s with %d", grid, count)
+	}
+
+	// No single change improves this grid.
+	grid, count, err = s.ImproveByOneSwap("cabt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if grid != "cabt" || count != 4 {
+		t.Fatalf("expected unchanged grid with 4 words, got %s with %d", grid, count)
+	}
+
+	if _, _, err = s.ImproveByOneSwap("ca1t"); !errors.Is(err, ErrInvalidCharacter) {
+		t.Fatal("expected ErrInvalidCharacter, got", err)
+	}
+}
+
+func TestSampleSolutions(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflvctor"
+	all, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sample, err := s.SampleSolutions(grid, 5, rand.New(rand.NewSource(42)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sample) != 5 {
+		t.Fatal("wrong number of words:", len(sample))
+	}
+	seen := make(map[string]bool)
+	for _, w := range sample {
+		if seen[w] {
+			t.Fatal("duplicate word:", w)
+		}
+		seen[w] = true
+		if !slices.Contains(all, w) {
+			t.Fatal("word is not a solution:", w)
+		}
+	}
+
+	// The same seed chooses the same words, even when results are unsorted.
+	u, err := New(4, 5, "", WithSortResults(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := u.SampleSolutions(grid, 5, rand.New(rand.NewSource(42)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sample, again) {
+		t.Fatalf("same seed chose %v, then %v", sample, again)
+	}
+
+	sample, err = s.SampleSolutions(grid, len(all)+10, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sample) != len(all) {
+		t.Fatalf("expected all %d words, got %d", len(all), len(sample))
+	}
+	slices.Sort(sample)
+	if !reflect.DeepEqual(sample, all) {
+		t.Fatal("expected all solutions")
+	}
+
+	if sample, err = s.SampleSolutions(grid, 0, nil); err != nil || len(sample) != 0 {
+		t.Fatal("expected no words, got", sample, err)
+	}
+}
+
+func TestGenerateLengths(t *testing.T) {
+	s, err := New(4, 4, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := map[int]int{3: 10, 4: 5}
+	grid, counts, err := s.GenerateLengths(context.Background(), target, 200, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := s.LengthQuality(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(counts, q.Counts) {
+		t.Fatalf("returned counts %v do not match grid counts %v", counts, q.Counts)
+	}
+	// The search is best effort, so only check that the grid is close.
+	var dist int
+	for length := 1; length <= s.BoardSize(); length++ {
+		d := counts[length] - target[length]
+		dist += max(d, -d)
+	}
+	if dist > 10 {
+		t.Fatalf("grid %q is not close to target, counts %v", grid, counts)
+	}
+
+	// The same seed generates the same grid.
+	again, _, err := s.GenerateLengths(context.Background(), target, 200, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != grid {
+		t.Fatalf("same seed generated %q, then %q", grid, again)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	grid, _, err = s.GenerateLengths(ctx, target, 200, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatal("expected context.Canceled, got", err)
+	}
+	if len(grid) != s.BoardSize() {
+		t.Fatal("expected closest grid with canceled context")
+	}
+
+	if _, _, err = s.GenerateLengths(context.Background(), map[int]int{3: -1}, 10, nil); err == nil {
+		t.Fatal("expected error for negative count")
+	}
+	if _, _, err = s.GenerateLengths(context.Background(), target, 0, nil); err == nil {
+		t.Fatal("expected error for no attempts")
+	}
+}
@@ -0,0 +1,117 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Incremental solves a board whose squares are revealed one at a time, such
+// as in a game where the board fills in during play. After each square is
+// revealed, only the words that the new square makes possible are returned.
+//
+// A word that becomes possible when a square is revealed must have a path
+// that passes through that square, and all other squares on the path must
+// already be revealed. So, rather than solving the whole board again, the
+// search starts only from revealed squares that are connected to the new
+// square through other revealed squares, and only words with a path through
+// the new square that were not previously found are returned. The words found
+// so far are retained between reveals.
+type Incremental struct {
+	s     Solver
+	board []byte
+	found map[string]struct{}
+	sc    Scratch
+}
+
+// NewIncremental returns an Incremental that uses the Solver to solve a board
+// that starts with all squares unrevealed.
+func (s Solver) NewIncremental() *Incremental {
+	board := make([]byte, s.BoardSize())
+	for i := range board {
+		board[i] = FillPlaceholder
+	}
+	return &Incremental{
+		s:     s,
+		board: board,
+		found: make(map[string]struct{}),
+	}
+}
+
+// Reveal sets the letter of an unrevealed square and returns the words that
+// are newly found as a result. The returned words are sorted.
+func (inc *Incremental) Reveal(sq int, letter byte) ([]string, error) {
+	s := inc.s
+	if s.dict == nil {
+		return nil, ErrNoDictionary
+	}
+	if sq < 0 || sq >= len(inc.board) || (s.mask != nil && !s.mask[sq]) {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidSquare, sq)
+	}
+	if inc.board[sq] != FillPlaceholder {
+		return nil, errors.New("square already revealed")
+	}
+	letter |= 0x20 // lower case
+	if letter < 'a' || letter > 'z' {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidCharacter, letter)
+	}
+	inc.board[sq] = letter
+	board := string(inc.board)
+
+	var words []string
+	found := func(w string, path []int) bool {
+		word, ok := s.output(w)
+		if !ok {
+			return true
+		}
+		if _, ok = inc.found[word]; ok {
+			return true
+		}
+		for _, p := range path {
+			if p == sq {
+				inc.found[word] = struct{}{}
+				words = append(words, word)
+				break
+			}
+		}
+		return true
+	}
+	inc.sc.resetNodes()
+	for _, initSq := range inc.region(sq) {
+		if !s.search(&inc.sc, board, initSq, found) {
+			break
+		}
+	}
+	return uniqueSortedWords(words), inc.sc.nodeErr()
+}
+
+// Grid returns the current grid, with FillPlaceholder in each square that is
+// not yet revealed.
+func (inc *Incremental) Grid() string {
+	return string(inc.board)
+}
+
+// Words returns all words found so far, sorted.
+func (inc *Incremental) Words() []string {
+	var words []string
+	for w := range inc.found {
+		words = append(words, w)
+	}
+	return uniqueSortedWords(words)
+}
+
+// region returns the revealed squares that are connected to sq through other
+// revealed squares, including sq.
+func (inc *Incremental) region(sq int) []int {
+	inRegion := make([]bool, len(inc.board))
+	inRegion[sq] = true
+	region := []int{sq}
+	for i := 0; i < len(region); i++ {
+		for _, a := range inc.s.adj[region[i]] {
+			if !inRegion[a] && inc.board[a] != FillPlaceholder {
+				inRegion[a] = true
+				region = append(region, a)
+			}
+		}
+	}
+	return region
+}
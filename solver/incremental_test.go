@@ -0,0 +1,70 @@
+package solver
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestIncremental(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const grid = "qadfetriihkriflvctor"
+
+	inc := s.NewIncremental()
+	rng := rand.New(rand.NewSource(1))
+	var all []string
+	for _, sq := range rng.Perm(len(grid)) {
+		words, err := inc.Reveal(sq, grid[sq])
+		if err != nil {
+			t.Fatal(err)
+		}
+		all = append(all, words...)
+		// Compare with full solve of partially revealed board.
+		expect := solvePartial(s, inc.Grid())
+		if !reflect.DeepEqual(uniqueSortedWords(append([]string(nil), all...)), expect) {
+			t.Fatal("incremental words do not match full solve of", inc.Grid())
+		}
+		if !reflect.DeepEqual(inc.Words(), expect) {
+			t.Fatal("found words do not match full solve of", inc.Grid())
+		}
+	}
+
+	expect, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(inc.Words(), expect) {
+		t.Fatal("incremental words do not match solve of full grid")
+	}
+	if len(all) != len(expect) {
+		t.Fatal("words returned by more than one reveal")
+	}
+
+	if _, err = inc.Reveal(0, 'a'); err == nil {
+		t.Fatal("failed to catch square already revealed")
+	}
+	inc = s.NewIncremental()
+	if _, err = inc.Reveal(20, 'a'); !errors.Is(err, ErrInvalidSquare) {
+		t.Fatal("expected ErrInvalidSquare, got", err)
+	}
+	if _, err = inc.Reveal(0, '?'); !errors.Is(err, ErrInvalidCharacter) {
+		t.Fatal("expected ErrInvalidCharacter, got", err)
+	}
+}
+
+// solvePartial solves a board with unrevealed squares.
+func solvePartial(s Solver, board string) []string {
+	var words []string
+	sc := &Scratch{}
+	for sq := range board {
+		s.search(sc, board, sq, func(word string, _ []int) bool {
+			words = append(words, s.word(word))
+			return true
+		})
+	}
+	return uniqueSortedWords(words)
+}
@@ -0,0 +1,359 @@
+package solver
+
+// Option is a functional option that configures a Solver created by New.
+type Option func(*config)
+
+type config struct {
+	audit         AuditFunc
+	unsorted      bool
+	wordCase      WordCase
+	denyPath      string
+	nodeLimit     int
+	origin        Origin
+	backend       Backend
+	progress      func(wordsLoaded int)
+	reverse       bool
+	canonical     bool
+	resultCap     int
+	pad           bool
+	sep           string
+	scoreFn       ScoreFunc
+	wordFilter    func(word string) (string, bool)
+	freqColumn    bool
+	maxBoardSize  int
+	caseSensitive bool
+	fpRate        float64
+	minLen        int
+	minLenSet     bool
+	maxLen        int
+	maxLenSet     bool
+	workers       int
+}
+
+// WordCase selects the letter case of words returned by a Solver.
+type WordCase int
+
+const (
+	// LowerCase returns words in all lower case, such as "queen".
+	LowerCase WordCase = iota
+	// UpperCase returns words in all upper case, such as "QUEEN".
+	UpperCase
+	// TitleCase returns words with only the first letter in upper case, such
+	// as "Queen".
+	TitleCase
+)
+
+// Origin selects the corner of the board where a grid string begins.
+type Origin int
+
+const (
+	// TopLeft means the grid string begins with the top left square of the
+	// board, and each row of the string is the row below the previous one.
+	TopLeft Origin = iota
+	// BottomLeft means the grid string begins with the bottom left square of
+	// the board, and each row of the string is the row above the previous one.
+	BottomLeft
+)
+
+// AuditFunc is called for every entry scanned from a words file. The accepted
+// argument reports whether the word was put into the dictionary, and reason
+// gives the reason a word was rejected, or is empty if it was accepted. A word
+// that is accepted and then removed by the deny file set by WithDenyFile is
+// reported again, as not accepted with ReasonDenied.
+type AuditFunc func(word string, accepted bool, reason string)
+
+// Reasons given to an AuditFunc for rejecting a word.
+const (
+	ReasonTooLong     = "too long"
+	ReasonTooShort    = "too short"
+	ReasonCapitalized = "capitalized"
+	ReasonQWithoutU   = "q not followed by u"
+	ReasonDenied      = "denied"
+)
+
+func getOpts(opts []Option) config {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithAudit sets a function that is called with the filtering decision made
+// for every entry scanned from the words file. This is useful for auditing
+// curated dictionaries. When not set, no audit cost is incurred.
+func WithAudit(fn AuditFunc) Option {
+	return func(c *config) {
+		c.audit = fn
+	}
+}
+
+// WithSortResults sets whether Solve sorts the words it returns. Sorting is
+// enabled by default. When disabled, the words returned by Solve are still
+// unique, and are in the order they are first found on the board. Duplicate
+// words are then dropped as they are found, instead of by sorting all the
+// words found. This avoids the cost of sorting for callers that re-sort
+// results or only count them.
+func WithSortResults(sorted bool) Option {
+	return func(c *config) {
+		c.unsorted = !sorted
+	}
+}
+
+// WithWordCase sets the letter case of the words returned by a Solver. The
+// default is LowerCase.
+func WithWordCase(wordCase WordCase) Option {
+	return func(c *config) {
+		c.wordCase = wordCase
+	}
+}
+
+// WithDenyFile sets a file of words to remove from the dictionary after it is
+// loaded. This excludes words without editing the words file. Words in the
+// deny file are matched without regard to case, and may be gz compressed the
+// same as the words file.
+func WithDenyFile(path string) Option {
+	return func(c *config) {
+		c.denyPath = path
+	}
+}
+
+// WithNodeLimit caps the number of search nodes explored while solving a
+// single grid. Each node is a path of squares that spells a prefix of some
+// dictionary word. When the limit is reached, the search stops and the solve
+// returns ErrNodeLimit along with the words found up to that point, so the
+// results may be incomplete. This bounds the work done for adversarial boards,
+// and is useful when solving untrusted input. A limit of zero, the default,
+// means no limit.
+func WithNodeLimit(n int) Option {
+	return func(c *config) {
+		c.nodeLimit = n
+	}
+}
+
+// WithOrigin sets the corner of the board where grid strings begin. The
+// default is TopLeft. Use BottomLeft for grids from board editors that index
+// squares from the bottom left.
+//
+// Square indexes are always positions in the grid string, so the origin
+// affects how those squares are laid out on the board: which row Grid prints
+// at the top, and which direction DirectionsFor calls north. The words found
+// in a grid do not depend on the origin.
+func WithOrigin(origin Origin) Option {
+	return func(c *config) {
+		c.origin = origin
+	}
+}
+
+// WithBackend sets the data structure that holds the dictionary. The default
+// is RadixTree. Use CompactTrie to reduce the memory used by a Solver with a
+// large dictionary, or BloomFilter to use the least memory at the cost of
+// sometimes finding words that are not in the dictionary.
+func WithBackend(backend Backend) Option {
+	return func(c *config) {
+		c.backend = backend
+	}
+}
+
+// WithFalsePositiveRate sets the chance that the BloomFilter backend reports
+// a word or prefix that is not in the dictionary, for each word or prefix
+// looked up. A lower rate uses more memory: about 1.44 * log2(1/rate) bits for
+// each word and each distinct prefix of the words. A rate that is not between
+// 0 and 1 means DefaultFalsePositiveRate, the default. This has no effect on
+// other backends.
+func WithFalsePositiveRate(rate float64) Option {
+	return func(c *config) {
+		c.fpRate = rate
+	}
+}
+
+// LoadProgressInterval is the number of words loaded between calls to the
+// function set by WithLoadProgress.
+const LoadProgressInterval = 10000
+
+// WithLoadProgress sets a function that is called while the words file is
+// loaded, to report progress when loading a large words file. The function is
+// called with the number of words loaded so far after every
+// LoadProgressInterval words, and once more with the total number of words
+// loaded if that was not already reported. Words that are filtered out are not
+// counted.
+func WithLoadProgress(fn func(wordsLoaded int)) Option {
+	return func(c *config) {
+		c.progress = fn
+	}
+}
+
+// WithReverseIndex sets whether the Solver builds a reverse index of its
+// dictionary, which holds every word spelled backward. The reverse index makes
+// SolveEndingAt search only from the given square instead of the whole board.
+// Building the reverse index about doubles the time to create the Solver and
+// the memory used by its dictionary, so it is disabled by default.
+func WithReverseIndex(enable bool) Option {
+	return func(c *config) {
+		c.reverse = enable
+	}
+}
+
+// WithCanonicalPaths sets whether SolvePaths treats a path and the same path
+// traced in reverse as the same path, when both spell the same word. This is
+// disabled by default. Since a path traced in reverse spells the word
+// backward, this only affects palindromes, such as "tat". When enabled, only
+// the one of the two paths whose squares compare less, square by square, is
+// returned. For example, of the paths 0, 1, 2 and 2, 1, 0, only 0, 1, 2 is
+// returned.
+func WithCanonicalPaths(enable bool) Option {
+	return func(c *config) {
+		c.canonical = enable
+	}
+}
+
+// DefaultResultCapacity is the number of words that a Solver allocates room
+// for when it begins collecting the results of a solve.
+const DefaultResultCapacity = 256
+
+// WithResultCapacity sets the number of words that a Solver allocates room for
+// when it begins collecting the results of a solve. Setting this to the number
+// of words expected for the boards being solved avoids growing the result
+// buffer on dense boards, and avoids allocating more than is needed on sparse
+// boards. A capacity less than one means DefaultResultCapacity, the default.
+func WithResultCapacity(n int) Option {
+	return func(c *config) {
+		c.resultCap = n
+	}
+}
+
+// WithPadding sets whether a Solver accepts a grid that is shorter than the
+// board. This is disabled by default, and a short grid is an error. When
+// enabled, a short grid fills the board from its first square, and the rest
+// of the squares are blank. A blank square is never part of any path through
+// the board, the same as an absent square of a masked board. This allows
+// solving a board while it is being filled in. A grid that is longer than the
+// board is still an error.
+func WithPadding(enable bool) Option {
+	return func(c *config) {
+		c.pad = enable
+	}
+}
+
+// WithWordSeparator sets the separator between words on each line of the
+// words file. The default is a newline, meaning that each line holds one word.
+// For word sources that put many words on each line, such as separated by
+// spaces, each line is split on sep, and every word is filtered and put into
+// the dictionary the same as a word on its own line. Empty words, as between
+// repeated separators, are ignored. The deny file always has one word per
+// line.
+func WithWordSeparator(sep string) Option {
+	return func(c *config) {
+		c.sep = sep
+	}
+}
+
+// WithScoreFunc sets the function used to score words, such as by Leaderboard
+// and BestWord. The default scores words using Score, which depends only on
+// the word. Use this for variants that score words differently, including by
+// the number of squares used to trace a word. For example, a bonus for a word
+// that uses every tile on the board is given when pathLen equals the board
+// size.
+func WithScoreFunc(fn ScoreFunc) Option {
+	return func(c *config) {
+		c.scoreFn = fn
+	}
+}
+
+// WithWordFilter sets a function that is called with each word found while
+// solving, in the Solver's word case. The word that fn returns replaces the
+// found word in the results, and returning false drops the word from the
+// results. This allows changing words, such as into display forms, and
+// rejecting words by any rule, such as with a profanity filter.
+//
+// The filter is called each time a word is found, which is more than once for
+// a word that can be traced more than one way. Words are filtered before they
+// are deduplicated and sorted, so results are deduplicated and sorted by the
+// words that fn returns.
+func WithWordFilter(fn func(word string) (string, bool)) Option {
+	return func(c *config) {
+		c.wordFilter = fn
+	}
+}
+
+// WithFrequencyColumn sets whether each line of the words file has a second
+// column, separated from the word by white space, that gives the frequency of
+// the word, such as "queen 12345". The frequencies are used by Frequency and
+// SolveRarest. A line without a second column, or with one that is not a
+// number, gives the word a frequency of zero. This is disabled by default, and
+// when enabled, any separator set by WithWordSeparator is not used.
+func WithFrequencyColumn(enable bool) Option {
+	return func(c *config) {
+		c.freqColumn = enable
+	}
+}
+
+// WithCaseSensitive sets whether the Solver matches letters by case. When
+// enabled, words in the words file that begin with a capital letter, such as
+// proper nouns, are kept instead of rejected, and words are stored as spelled.
+// Grids are not lower cased, so an upper case letter in a grid matches only
+// an upper case letter in a word, such as 'P' for "Paris" in a puzzle of
+// names. A Qu tile is given as 'q' for words with "qu", or 'Q' for words with
+// "Qu". This is disabled by default, and words and grids are matched without
+// regard to case.
+//
+// Methods that compare words, such as SolveExcluding and ValidateWords, still
+// match words without regard to case.
+func WithCaseSensitive(enable bool) Option {
+	return func(c *config) {
+		c.caseSensitive = enable
+	}
+}
+
+// WithMinLength sets the minimum number of letters in the words that the
+// Solver searches for, such as 2 for a game for children, or 4 for a harder
+// game. Shorter words in the words file are not loaded. The default is
+// DefaultMinWordLength. New returns an error wrapping ErrInvalidWordLength if
+// n is less than one or more than the number of squares on the board.
+func WithMinLength(n int) Option {
+	return func(c *config) {
+		c.minLen = n
+		c.minLenSet = true
+	}
+}
+
+// WithMaxLength sets the maximum number of letters in the words that the
+// Solver searches for. Longer words in the words file are not loaded, which
+// makes a smaller dictionary that loads faster for a large board, where the
+// board allows words far longer than any in the words file. The maximum is
+// never more than the number of squares on the board, which is the default.
+// New returns an error wrapping ErrInvalidWordLength if n is less than the
+// minimum word length.
+func WithMaxLength(n int) Option {
+	return func(c *config) {
+		c.maxLen = n
+		c.maxLenSet = true
+	}
+}
+
+// WithConcurrency sets the number of goroutines that Solve uses to search a
+// board, each searching from a share of the board's squares. A number less
+// than one means runtime.NumCPU(), the default. Use 1 to search using only
+// the calling goroutine.
+//
+// When searching with more than one goroutine, a word filter set by
+// WithWordFilter may be called concurrently.
+func WithConcurrency(n int) Option {
+	return func(c *config) {
+		c.workers = n
+	}
+}
+
+// DefaultMaxBoardSize is the largest number of squares on a board for which
+// New creates a Solver, unless set otherwise by WithMaxBoardSize.
+const DefaultMaxBoardSize = 10000
+
+// WithMaxBoardSize sets the largest number of squares on a board for which New
+// creates a Solver. Larger boards are rejected with ErrBoardTooLarge. This
+// guards against board dimensions from untrusted input that would use too much
+// memory. A size less than one means DefaultMaxBoardSize, the default.
+func WithMaxBoardSize(n int) Option {
+	return func(c *config) {
+		c.maxBoardSize = n
+	}
+}
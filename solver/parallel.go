@@ -0,0 +1,79 @@
+package solver
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// minParallelSquares is the fewest squares on a board that Solve searches
+// using multiple goroutines. Smaller boards are searched faster than the time
+// to start the goroutines.
+const minParallelSquares = 64
+
+// chunksPerWorker is the number of chunks of starting squares made for each
+// goroutine, so that goroutines that search quickly take more chunks.
+const chunksPerWorker = 4
+
+// concurrency returns the number of goroutines that Solve uses.
+func (s Solver) concurrency() int {
+	if s.cfg.workers < 1 {
+		return runtime.NumCPU()
+	}
+	return s.cfg.workers
+}
+
+// solveParallel generates all solutions for a board that has been checked by
+// board, using the given number of goroutines. The board's squares are divided
+// into chunks, and each goroutine searches from the squares of one chunk at a
+// time, using its own Scratch. The words found from each chunk are merged in
+// the order of the chunks, so the results are the same as searching from each
+// square in order.
+func (s Solver) solveParallel(board string, workers int) []string {
+	nChunks := min(len(board), workers*chunksPerWorker)
+	chunkSize := (len(board) + nChunks - 1) / nChunks
+	nChunks = (len(board) + chunkSize - 1) / chunkSize
+	results := make([][]string, nChunks)
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, nChunks); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sc := &Scratch{}
+			for {
+				chunk := int(next.Add(1) - 1)
+				if chunk >= nChunks {
+					return
+				}
+				var words []string
+				found := func(word string, _ []int) bool {
+					if out, ok := s.output(word); ok {
+						words = append(words, out)
+					}
+					return true
+				}
+				end := min(len(board), (chunk+1)*chunkSize)
+				for initSq := chunk * chunkSize; initSq < end; initSq++ {
+					s.search(sc, board, initSq, found)
+				}
+				results[chunk] = words
+			}
+		}()
+	}
+	wg.Wait()
+
+	var n int
+	for _, words := range results {
+		n += len(words)
+	}
+	all := make([]string, 0, n)
+	for _, words := range results {
+		all = append(all, words...)
+	}
+	if s.cfg.unsorted {
+		return uniqueWords(all, nil)
+	}
+	return uniqueSortedWords(all)
+}
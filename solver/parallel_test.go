@@ -0,0 +1,92 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSolveConcurrency(t *testing.T) {
+	const size = 30 * 30
+	rng := rand.New(rand.NewSource(1))
+	grid, err := FillGrid(strings.Repeat(string(FillPlaceholder), size), size, rng)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Load the dictionary once, and vary only the configuration of copies of
+	// the Solver, which share the dictionary.
+	serial, err := New(30, 30, "", WithConcurrency(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sorted := range []bool{true, false} {
+		serial.cfg.unsorted = !sorted
+		expect, err := serial.Solve(grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, workers := range []int{0, 2, 3, 16} {
+			s := serial
+			s.cfg.workers = workers
+			words, err := s.Solve(grid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Results are in the same order as a search by one goroutine,
+			// whether or not they are sorted.
+			if !reflect.DeepEqual(words, expect) {
+				t.Fatalf("%d workers, sorted=%v: got %d words, expected %d", workers, sorted, len(words), len(expect))
+			}
+		}
+	}
+
+	// A node limit searches using one goroutine, so the limit is exact.
+	s := serial
+	s.cfg.workers = 4
+	s.cfg.nodeLimit = 100
+	if _, err = s.Solve(grid); !errors.Is(err, ErrNodeLimit) {
+		t.Fatal("expected ErrNodeLimit, got", err)
+	}
+}
+
+func TestAdjacencyTableConcurrent(t *testing.T) {
+	// Tables made at the same time, as when creating Solvers concurrently, must
+	// not share buffers.
+	expect := adjacencyTable(7, 5, nil)
+	var wg sync.WaitGroup
+	tables := make([][][]int, 8)
+	for i := range tables {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tables[i] = adjacencyTable(7, 5, nil)
+		}(i)
+	}
+	wg.Wait()
+	for _, table := range tables {
+		if !reflect.DeepEqual(table, expect) {
+			t.Fatal("wrong adjacency table")
+		}
+	}
+}
+
+func BenchmarkSolveConcurrency(b *testing.B) {
+	const xlen = 50
+	const ylen = 50
+	grid := genGrid(xlen * ylen)
+	for _, workers := range []int{1, runtime.NumCPU()} {
+		b.Run(fmt.Sprint("workers=", workers), func(b *testing.B) {
+			s, _ := New(xlen, ylen, "", WithConcurrency(workers))
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				s.Solve(grid)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package solver
+
+import "fmt"
+
+// ParseGrid converts a grid in a loosely formatted form, such as one copied
+// from a website, into the flat grid string used by Solve. The input is
+// expected to describe size cells.
+//
+// Spaces, slashes, pipes, commas, and line breaks between cells are ignored,
+// as are the '+' and '-' characters that draw grid lines, so the output of
+// GridString can also be parsed. The Qu tile may be written as "Qu" or "Q",
+// and in either case becomes the single cell 'q'. Letters may be of any case,
+// and are returned in lower case.
+//
+// Example inputs for the same 2x2 grid:
+//
+//	"Qu A / T E"
+//	"qu|a\nt|e"
+//	"qate"
+func ParseGrid(input string, size int) (string, error) {
+	grid, _, err := ParseGridLocked(input, size)
+	return grid, err
+}
+
+// ParseGridLocked parses a grid the same as ParseGrid, and also returns which
+// cells are locked. A cell is locked if its letter is upper case in the input,
+// such as a pre-filled cell in a puzzle that players may not change. The
+// returned grid is in lower case for solving, and locked[i] is true if cell i
+// is locked. The Qu tile is locked if its Q is upper case.
+func ParseGridLocked(input string, size int) (string, []bool, error) {
+	grid := make([]byte, 0, size)
+	locked := make([]bool, 0, size)
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		switch c {
+		case ' ', '\t', '\n', '\r', '/', '|', ',', '+', '-':
+			continue
+		}
+		lc := c | 0x20 // lower case
+		if lc < 'a' || lc > 'z' {
+			return "", nil, fmt.Errorf("%w: %q", ErrInvalidCharacter, c)
+		}
+		if lc == 'q' && i+1 < len(input) && input[i+1]|0x20 == 'u' {
+			i++ // Qu tile
+		}
+		grid = append(grid, lc)
+		locked = append(locked, c != lc)
+	}
+	if err := checkGridSize(string(grid), size); err != nil {
+		return "", nil, err
+	}
+	return string(grid), locked, nil
+}
@@ -0,0 +1,94 @@
+package solver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseGrid(t *testing.T) {
+	const expect = "qadfetriihkriflv"
+	inputs := []string{
+		"qadfetriihkriflv",
+		"QADFETRIIHKRIFLV",
+		"Qu A D F / E T R I / I H K R / I F L V",
+		"qu a d f\ne t r i\ni h k r\ni f l v\n",
+		"Qu,A,D,F,E,T,R,I,I,H,K,R,I,F,L,V",
+		"|Qu|A|D|F|\r\n|E|T|R|I|\r\n|I|H|K|R|\r\n|I|F|L|V|",
+		GridString(expect, 4, 4),
+	}
+	for _, input := range inputs {
+		grid, err := ParseGrid(input, 16)
+		if err != nil {
+			t.Fatalf("failed to parse %q: %s", input, err)
+		}
+		if grid != expect {
+			t.Errorf("parsed %q as %q, expected %q", input, grid, expect)
+		}
+	}
+
+	// A q followed by a u cell must be separated.
+	grid, err := ParseGrid("q u i t", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if grid != "quit" {
+		t.Fatal("wrong grid:", grid)
+	}
+
+	_, err = ParseGrid("Qu A / T", 4)
+	if !errors.Is(err, ErrGridTooShort) {
+		t.Error("expected ErrGridTooShort, got", err)
+	}
+	_, err = ParseGrid("Qu A / T E / S", 4)
+	if !errors.Is(err, ErrGridTooLong) {
+		t.Error("expected ErrGridTooLong, got", err)
+	}
+	if _, err = ParseGrid("Qu A / T 3", 4); !errors.Is(err, ErrInvalidCharacter) {
+		t.Error("failed to catch invalid character")
+	}
+}
+
+func TestParseGridLocked(t *testing.T) {
+	grid, locked, err := ParseGridLocked("Qu a / t E", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if grid != "qate" {
+		t.Fatal("wrong grid:", grid)
+	}
+	if !reflect.DeepEqual(locked, []bool{true, false, false, true}) {
+		t.Fatal("wrong locked cells:", locked)
+	}
+
+	grid, locked, err = ParseGridLocked("CatSQuIT", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if grid != "catsqit" {
+		t.Fatal("wrong grid:", grid)
+	}
+	if !reflect.DeepEqual(locked, []bool{true, false, false, true, true, true, true}) {
+		t.Fatal("wrong locked cells:", locked)
+	}
+
+	s, err := New(2, 2, writeWords(t, "quit", "tea"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid, _, err = ParseGridLocked("QIte", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	words, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"quit"}) {
+		t.Fatal("wrong words:", words)
+	}
+
+	if _, _, err = ParseGridLocked("Qu A / T", 4); !errors.Is(err, ErrGridTooShort) {
+		t.Error("expected ErrGridTooShort, got", err)
+	}
+}
@@ -0,0 +1,352 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// WordPath is a word found on a board together with a path that spells it.
+type WordPath struct {
+	// Word is the word found.
+	Word string
+	// Path is the squares visited, in order, to spell the word.
+	Path []int
+	// Directions is the compass direction of each step along the path, as
+	// returned by DirectionsFor.
+	Directions []string
+}
+
+// compass holds the direction names for a step by dx and dy, indexed by
+// [dy+1][dx+1].
+var compass = [3][3]string{
+	{"NW", "N", "NE"},
+	{"W", "", "E"},
+	{"SW", "S", "SE"},
+}
+
+// DirectionsFor converts a path through the board into the compass direction
+// of each step along the path: N, NE, E, SE, S, SW, W, or NW, where N is
+// toward the top row of the board, as set by WithOrigin. The returned slice
+// has one fewer element than the path.
+//
+// Each square in the path is one step, including the Qu tile even though it
+// has two letters. A step between squares that are not adjacent has an empty
+// direction.
+func (s Solver) DirectionsFor(path []int) []string {
+	if len(path) < 2 {
+		return nil
+	}
+	dirs := make([]string, len(path)-1)
+	for i := range dirs {
+		dirs[i] = DirectionBetween(s.cols, path[i], path[i+1])
+		if s.cfg.origin == BottomLeft {
+			dirs[i] = flipNorthSouth(dirs[i])
+		}
+	}
+	return dirs
+}
+
+// DirectionBetween returns the compass direction of a step from square a to
+// square b on a board with the given number of columns, where N is toward the
+// top row and squares are numbered from the top left. An empty string is
+// returned if the squares are not adjacent, including squares at opposite
+// edges of consecutive rows, which are not adjacent even though their indexes
+// are consecutive.
+func DirectionBetween(cols, a, b int) string {
+	if cols < 1 || a < 0 || b < 0 {
+		return ""
+	}
+	dx := b%cols - a%cols
+	dy := b/cols - a/cols
+	if dx < -1 || dx > 1 || dy < -1 || dy > 1 {
+		return ""
+	}
+	return compass[dy+1][dx+1]
+}
+
+// flipNorthSouth returns the direction mirrored between north and south, for
+// boards where the grid string begins with the bottom row.
+func flipNorthSouth(dir string) string {
+	switch {
+	case strings.HasPrefix(dir, "N"):
+		return "S" + dir[1:]
+	case strings.HasPrefix(dir, "S"):
+		return "N" + dir[1:]
+	}
+	return dir
+}
+
+// IndexToXY converts a square index into the column x and row y of the square
+// on the board, both numbered from 0. The row is numbered from the row where
+// the grid string begins, as set by WithOrigin, so the square at index 0 is
+// always at 0, 0.
+func (s Solver) IndexToXY(sq int) (x, y int) {
+	return sq % s.cols, sq / s.cols
+}
+
+// WordCoords is a word found on a board together with the coordinates of the
+// squares of a path that spells it.
+type WordCoords struct {
+	// Word is the word found.
+	Word string `json:"word"`
+	// Coords holds the x and y coordinates, as returned by IndexToXY, of each
+	// square along the path.
+	Coords [][2]int `json:"coords"`
+}
+
+// SolveCoords generates all solutions for the given Boggle grid, and returns
+// each word with the coordinates of the squares of a path that spells it. This
+// suits visualizations that take arrays of coordinates instead of square
+// indexes. If a word can be traced more than one way, the first path returned
+// by SolvePaths is used. The results are sorted by word.
+func (s Solver) SolveCoords(grid string) ([]WordCoords, error) {
+	paths, err := s.SolvePaths(grid)
+	if err != nil && !errors.Is(err, ErrNodeLimit) {
+		return nil, err
+	}
+	var words []WordCoords
+	for i, wp := range paths {
+		if i != 0 && wp.Word == paths[i-1].Word {
+			continue
+		}
+		coords := make([][2]int, len(wp.Path))
+		for j, sq := range wp.Path {
+			x, y := s.IndexToXY(sq)
+			coords[j] = [2]int{x, y}
+		}
+		words = append(words, WordCoords{Word: wp.Word, Coords: coords})
+	}
+	return words, err
+}
+
+// FindPath finds a path through the given Boggle grid that spells the word,
+// and returns the squares of the path and true if the word can be traced on
+// the board. The word does not need to be in the Solver's dictionary. If there
+// is more than one such path, the path found first, beginning at the lowest
+// square, is returned.
+//
+// The word is matched without regard to case, and the Qu tile matches the
+// letters "qu" at the start of the word, the same as for Solve.
+func (s Solver) FindPath(grid, word string) ([]int, bool, error) {
+	board, err := s.board(grid)
+	if err != nil {
+		return nil, false, err
+	}
+	var found []int
+	s.tracePaths(board, normalizeWord(word, s.cfg.caseSensitive), func(path []int) bool {
+		found = slices.Clone(path)
+		return false
+	})
+	return found, found != nil, nil
+}
+
+// ShortestPath returns the shortest path through the given Boggle grid that
+// spells the word, for the cleanest highlight of a word that can be traced
+// more than one way. Every step between adjacent squares is one king move, so
+// all paths that spell a word have the same number of steps. Paths differ in
+// distance by their diagonal steps, which are longer than the steps across
+// rows or columns, so the shortest path is the one with the fewest diagonal
+// steps. If more than one path is the shortest, then the first when paths are
+// compared square by square is returned.
+//
+// The word does not need to be in the Solver's dictionary, and is matched the
+// same as for FindPath, with the Qu tile as one step. An error wrapping
+// ErrNoPath is returned if the word cannot be traced on the board.
+func (s Solver) ShortestPath(grid, word string) ([]int, error) {
+	board, err := s.board(grid)
+	if err != nil {
+		return nil, err
+	}
+	var best []int
+	bestDiag := -1
+	s.tracePaths(board, normalizeWord(word, s.cfg.caseSensitive), func(path []int) bool {
+		var diag int
+		for i := 1; i < len(path); i++ {
+			if path[i-1]/s.cols != path[i]/s.cols && path[i-1]%s.cols != path[i]%s.cols {
+				diag++
+			}
+		}
+		if bestDiag < 0 || diag < bestDiag || (diag == bestDiag && slices.Compare(path, best) < 0) {
+			best, bestDiag = append(best[:0], path...), diag
+		}
+		return true
+	})
+	if best == nil {
+		return nil, fmt.Errorf("%w: %q", ErrNoPath, word)
+	}
+	return best, nil
+}
+
+// IsPrefix reports whether the letters along a path through the given Boggle
+// grid begin any word in the Solver's dictionary. This tells a player tracing
+// a word that the trace so far could still become a word. A path that spells a
+// complete word is also a prefix, whether or not longer words begin with it.
+// Use this with FindPath or the solutions from Solve to also check whether the
+// path spells a word. An empty path is a prefix of every word.
+//
+// An error wrapping ErrInvalidSquare is returned if a square is not on the
+// board, and an error wrapping ErrInvalidPath is returned if the path visits a
+// square more than once or steps between squares that are not adjacent.
+func (s Solver) IsPrefix(grid string, path []int) (bool, error) {
+	board, err := s.board(grid)
+	if err != nil {
+		return false, err
+	}
+	visited := make([]bool, len(board))
+	for i, sq := range path {
+		if sq < 0 || sq >= len(board) || (s.mask != nil && !s.mask[sq]) {
+			return false, fmt.Errorf("%w: %d", ErrInvalidSquare, sq)
+		}
+		if visited[sq] {
+			return false, fmt.Errorf("%w: square %d visited more than once", ErrInvalidPath, sq)
+		}
+		if i != 0 && !slices.Contains(s.adj[path[i-1]], sq) {
+			return false, fmt.Errorf("%w: squares %d and %d not adjacent", ErrInvalidPath, path[i-1], sq)
+		}
+		visited[sq] = true
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	st := s.dict.stepper()
+	for _, sq := range path {
+		if !st.next(board[sq]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// tracePaths calls visit with each path through the board that spells the
+// given tiles, beginning with the paths from the lowest square. The path must
+// not be retained. Returning false from visit stops the search.
+func (s Solver) tracePaths(board, tiles string, visit func(path []int) bool) {
+	if tiles == "" {
+		return
+	}
+	path := make([]int, 0, len(tiles))
+	visited := make([]bool, len(board))
+	// trace returns false if visit stopped the search.
+	var trace func(sq int) bool
+	trace = func(sq int) bool {
+		if board[sq] != tiles[len(path)] {
+			return true
+		}
+		path = append(path, sq)
+		defer func() { path = path[:len(path)-1] }()
+		if len(path) == len(tiles) {
+			return visit(path)
+		}
+		visited[sq] = true
+		defer func() { visited[sq] = false }()
+		for _, next := range s.adj[sq] {
+			if !visited[next] && !trace(next) {
+				return false
+			}
+		}
+		return true
+	}
+	for sq := 0; sq < len(board); sq++ {
+		if s.mask != nil && !s.mask[sq] {
+			continue
+		}
+		if !trace(sq) {
+			return
+		}
+	}
+}
+
+// SolutionSheet returns a printable sheet showing where each of the given
+// words is on the board. For each word, the word is followed by a copy of the
+// grid with the squares of the word's path numbered in order, as returned by
+// GridStringWithPath. A word that cannot be traced on the board is followed by
+// a note saying so instead of a grid. Pass only the words of interest, such as
+// the longest words, to keep the sheet a manageable size.
+func (s Solver) SolutionSheet(grid string, words []string) (string, error) {
+	board, err := s.board(grid)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for i, w := range words {
+		if i != 0 {
+			sb.WriteByte('\n')
+		}
+		// The board is already checked, so trace the word on it directly
+		// instead of using FindPath, which checks the board again.
+		var path []int
+		s.tracePaths(board, normalizeWord(w, s.cfg.caseSensitive), func(p []int) bool {
+			path = slices.Clone(p)
+			return false
+		})
+		if path == nil {
+			fmt.Fprintf(&sb, "%s: not on board\n", w)
+			continue
+		}
+		sb.WriteString(w)
+		sb.WriteByte('\n')
+		sb.WriteString(gridStringWithPath(s.blankMasked(board), s.cols, s.rows, s.cfg.origin, path))
+	}
+	return sb.String(), nil
+}
+
+// SolvePaths generates all solutions for the given Boggle grid, the same as
+// Solve, and returns every path that spells each word. This gives the squares
+// and directions of each way to trace a word, for variants that score words
+// by how they are traced. The results are sorted by word, and the paths for
+// each word are sorted by their squares. Use WithCanonicalPaths to return a
+// path and its reverse only once.
+func (s Solver) SolvePaths(grid string) ([]WordPath, error) {
+	board, err := s.board(grid)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []WordPath
+	found := func(word string, path []int) bool {
+		if s.cfg.canonical && !canonicalPath(board, path) {
+			return true // reverse of path is returned instead
+		}
+		out, ok := s.output(word)
+		if !ok {
+			return true
+		}
+		paths = append(paths, WordPath{
+			Word:       out,
+			Path:       slices.Clone(path),
+			Directions: s.DirectionsFor(path),
+		})
+		return true
+	}
+	sc := &Scratch{}
+	for initSq := 0; initSq < len(board); initSq++ {
+		if !s.search(sc, board, initSq, found) {
+			break
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if paths[i].Word != paths[j].Word {
+			return paths[i].Word < paths[j].Word
+		}
+		return slices.Compare(paths[i].Path, paths[j].Path) < 0
+	})
+	return paths, sc.nodeErr()
+}
+
+// canonicalPath returns false if the path spells the same tiles in reverse,
+// and the reversed path compares less than the path, square by square.
+func canonicalPath(board string, path []int) bool {
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		if board[path[i]] != board[path[j]] {
+			return true // reverse spells different tiles
+		}
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		if path[i] != path[j] {
+			return path[i] < path[j]
+		}
+	}
+	return true
+}
@@ -0,0 +1,332 @@
+package solver
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDirectionsFor(t *testing.T) {
+	s, err := New(4, 4, writeWords(t, "cat"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// +---+---+---+---+
+	// | 0 | 1 | 2 | 3 |
+	// +---+---+---+---+
+	// | 4 | 5 | 6 | 7 |
+	// +---+---+---+---+
+	// | 8 | 9 | 10| 11|
+	// +---+---+---+---+
+	// | 12| 13| 14| 15|
+	// +---+---+---+---+
+	path := []int{0, 1, 6, 10, 9, 4, 0, 5, 2, 7, 11, 14, 13, 8}
+	expect := []string{"E", "SE", "S", "W", "NW", "N", "SE", "NE", "SE", "S", "SW", "W", "NW"}
+	dirs := s.DirectionsFor(path)
+	if !reflect.DeepEqual(dirs, expect) {
+		t.Fatalf("expected %v, got %v", expect, dirs)
+	}
+
+	// Squares 3 and 4 are not adjacent, and a square is not adjacent to itself.
+	dirs = s.DirectionsFor([]int{3, 4, 4, 9})
+	if !reflect.DeepEqual(dirs, []string{"", "", "SE"}) {
+		t.Fatal("wrong directions for non-adjacent squares:", dirs)
+	}
+
+	if len(s.DirectionsFor([]int{5})) != 0 {
+		t.Fatal("expected no directions for single square")
+	}
+}
+
+func TestDirectionBetween(t *testing.T) {
+	tests := []struct {
+		cols, a, b int
+		expect     string
+	}{
+		{4, 5, 1, "N"},
+		{4, 5, 2, "NE"},
+		{4, 5, 6, "E"},
+		{4, 5, 10, "SE"},
+		{4, 5, 9, "S"},
+		{4, 5, 8, "SW"},
+		{4, 5, 4, "W"},
+		{4, 5, 0, "NW"},
+		{4, 3, 4, ""}, // end of one row to start of next
+		{4, 4, 3, ""},
+		{4, 7, 8, ""},
+		{4, 0, 8, ""},
+		{4, 5, 5, ""},
+		{1, 0, 1, "S"},
+		{0, 0, 1, ""},
+		{4, -1, 0, ""},
+	}
+	for _, tc := range tests {
+		if dir := DirectionBetween(tc.cols, tc.a, tc.b); dir != tc.expect {
+			t.Errorf("DirectionBetween(%d, %d, %d) = %q, expected %q", tc.cols, tc.a, tc.b, dir, tc.expect)
+		}
+	}
+}
+
+func TestSolvePaths(t *testing.T) {
+	s, err := New(3, 3, writeWords(t, "tat", "tea", "quit"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// +---+---+---+
+	// | T | A | T |
+	// +---+---+---+
+	// | E | Qu| I |
+	// +---+---+---+
+	// | X | X | X |
+	// +---+---+---+
+	paths, err := s.SolvePaths("tateqixxx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []WordPath{
+		{"quit", []int{4, 5, 2}, []string{"E", "N"}},
+		{"tat", []int{0, 1, 2}, []string{"E", "E"}},
+		{"tat", []int{2, 1, 0}, []string{"W", "W"}},
+		{"tea", []int{0, 3, 1}, []string{"S", "NE"}},
+	}
+	if !reflect.DeepEqual(paths, expect) {
+		t.Fatalf("expected %v, got %v", expect, paths)
+	}
+
+	words, err := s.Solve("tateqixxx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"quit", "tat", "tea"}) {
+		t.Fatal("wrong words:", words)
+	}
+}
+
+func TestSolvePathsCanonical(t *testing.T) {
+	s, err := New(3, 3, writeWords(t, "tat", "tea", "quit"), WithCanonicalPaths(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// +---+---+---+
+	// | T | A | T |
+	// +---+---+---+
+	// | E | Qu| I |
+	// +---+---+---+
+	// | X | X | T |
+	// +---+---+---+
+	paths, err := s.SolvePaths("tateqixxt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The path 2, 1, 0 is the reverse of 0, 1, 2, and both spell "tat", so
+	// only 0, 1, 2 is returned.
+	expect := []WordPath{
+		{"quit", []int{4, 5, 2}, []string{"E", "N"}},
+		{"quit", []int{4, 5, 8}, []string{"E", "S"}},
+		{"tat", []int{0, 1, 2}, []string{"E", "E"}},
+		{"tea", []int{0, 3, 1}, []string{"S", "NE"}},
+	}
+	if !reflect.DeepEqual(paths, expect) {
+		t.Fatalf("expected %v, got %v", expect, paths)
+	}
+}
+
+func TestFindPath(t *testing.T) {
+	s, err := New(3, 2, writeWords(t, "cat"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// +---+---+---+
+	// | C | A | T |
+	// +---+---+---+
+	// | Qu| I | T |
+	// +---+---+---+
+	grid := "catqit"
+	for word, expect := range map[string][]int{
+		"cat":  {0, 1, 2},
+		"QUIT": {3, 4, 2},
+		"tact": nil,
+		"ai":   {1, 4},
+		"tit":  {2, 4, 5},
+		"cc":   nil,
+		"":     nil,
+	} {
+		path, ok, err := s.FindPath(grid, word)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != (expect != nil) || !reflect.DeepEqual(path, expect) {
+			t.Errorf("wrong path for %q: %v", word, path)
+		}
+	}
+}
+
+func TestSolutionSheet(t *testing.T) {
+	s, err := New(3, 2, writeWords(t, "cat", "quit"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sheet, err := s.SolutionSheet("catqit", []string{"quit", "dog"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const expect = `quit
++------+------+------+
+|   C  |   A  | 3 T  |
++------+------+------+
+| 1 Qu | 2 I  |   T  |
++------+------+------+
+
+dog: not on board
+`
+	if sheet != expect {
+		t.Fatalf("wrong solution sheet:\n%s", sheet)
+	}
+
+	if _, err = s.SolutionSheet("cat", []string{"cat"}); !errors.Is(err, ErrGridTooShort) {
+		t.Fatal("expected ErrGridTooShort, got", err)
+	}
+
+	// A short grid is padded when the Solver was created using WithPadding.
+	s, err = New(3, 2, writeWords(t, "cat", "quit"), WithPadding(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sheet, err = s.SolutionSheet("cat", []string{"cat"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const expectPadded = `cat
++------+------+------+
+| 1 C  | 2 A  | 3 T  |
++------+------+------+
+|   .  |   .  |   .  |
++------+------+------+
+`
+	if sheet != expectPadded {
+		t.Fatalf("wrong padded solution sheet:\n%s", sheet)
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	s, err := New(3, 3, writeWords(t, "cat"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// +---+---+---+
+	// | C |   |   |
+	// +---+---+---+
+	// |   | A |   |
+	// +---+---+---+
+	// | C | A | T |
+	// +---+---+---+
+	grid := "cxxxaxcat"
+	path, ok, err := s.FindPath(grid, "cat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || !reflect.DeepEqual(path, []int{0, 4, 8}) {
+		t.Fatal("wrong first path:", path)
+	}
+	path, err = s.ShortestPath(grid, "Cat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(path, []int{6, 7, 8}) {
+		t.Fatal("wrong shortest path:", path)
+	}
+
+	// The Qu tile is one step.
+	path, err = s.ShortestPath("qxxitxxxx", "quit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(path, []int{0, 3, 4}) {
+		t.Fatal("wrong shortest path:", path)
+	}
+
+	if _, err = s.ShortestPath(grid, "act"); !errors.Is(err, ErrNoPath) {
+		t.Fatal("expected ErrNoPath, got", err)
+	}
+	if _, err = s.ShortestPath("cat", "cat"); !errors.Is(err, ErrGridTooShort) {
+		t.Fatal("expected ErrGridTooShort, got", err)
+	}
+}
+
+func TestSolveCoords(t *testing.T) {
+	s, err := New(3, 2, writeWords(t, "cat", "quit"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x, y := s.IndexToXY(5); x != 2 || y != 1 {
+		t.Fatalf("wrong coordinates for square 5: %d, %d", x, y)
+	}
+	// +---+---+---+
+	// | C | A | T |
+	// +---+---+---+
+	// | Qu| I | X |
+	// +---+---+---+
+	words, err := s.SolveCoords("catqix")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []WordCoords{
+		{Word: "cat", Coords: [][2]int{{0, 0}, {1, 0}, {2, 0}}},
+		{Word: "quit", Coords: [][2]int{{0, 1}, {1, 1}, {2, 0}}},
+	}
+	if !reflect.DeepEqual(words, expect) {
+		t.Fatalf("expected %v, got %v", expect, words)
+	}
+	data, err := json.Marshal(words[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"word":"quit","coords":[[0,1],[1,1],[2,0]]}` {
+		t.Fatal("wrong JSON:", string(data))
+	}
+}
+
+func TestIsPrefix(t *testing.T) {
+	s, err := New(3, 2, writeWords(t, "cats", "quit"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// +---+---+---+
+	// | C | A | T |
+	// +---+---+---+
+	// | Qu| I | S |
+	// +---+---+---+
+	grid := "catqis"
+	for _, tc := range []struct {
+		path   []int
+		expect bool
+	}{
+		{[]int{0, 1}, true},       // "ca" begins "cats"
+		{[]int{0, 1, 2, 5}, true}, // "cats" is a word
+		{[]int{3, 4, 2}, true},    // "quit" is a word
+		{[]int{3, 4}, true},       // "qui" begins "quit"
+		{[]int{1, 2}, false},      // "at" is dead
+		{[]int{0, 4, 5}, false},   // "cis" is dead
+		{[]int{}, true},
+	} {
+		ok, err := s.IsPrefix(grid, tc.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != tc.expect {
+			t.Errorf("expected %v for path %v", tc.expect, tc.path)
+		}
+	}
+
+	if _, err = s.IsPrefix(grid, []int{0, 6}); !errors.Is(err, ErrInvalidSquare) {
+		t.Error("expected ErrInvalidSquare, got", err)
+	}
+	if _, err = s.IsPrefix(grid, []int{0, 2}); !errors.Is(err, ErrInvalidPath) {
+		t.Error("expected ErrInvalidPath for squares not adjacent, got", err)
+	}
+	if _, err = s.IsPrefix(grid, []int{0, 1, 0}); !errors.Is(err, ErrInvalidPath) {
+		t.Error("expected ErrInvalidPath for repeated square, got", err)
+	}
+}
@@ -0,0 +1,138 @@
+package solver
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strconv"
+	"strings"
+)
+
+// EncodePuzzle returns a compact, URL-safe token that represents the given
+// grid and its dimensions. The token can be included in a link to share the
+// puzzle, and is decoded by DecodePuzzle.
+//
+// Each cell of the grid is a single letter. The Qu tile is given as 'q', the
+// same as for Solve, and is encoded as a single cell. An error wrapping
+// ErrInvalidDimensions is returned if the number of letters in the grid is not
+// cols * rows, and an error wrapping ErrInvalidCharacter is returned if the
+// grid has a character that is not a letter, so that no token is created that
+// DecodePuzzle rejects.
+func EncodePuzzle(grid string, cols, rows int) (string, error) {
+	grid, err := checkPuzzleGrid(grid, cols, rows)
+	if err != nil {
+		return "", err
+	}
+	buf := make([]byte, 0, 2*binary.MaxVarintLen16+len(grid))
+	buf = binary.AppendUvarint(buf, uint64(cols))
+	buf = binary.AppendUvarint(buf, uint64(rows))
+	buf = append(buf, grid...)
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// checkPuzzleGrid checks that the grid has cols * rows letters, and returns
+// the grid in lower case.
+func checkPuzzleGrid(grid string, cols, rows int) (string, error) {
+	if cols < 1 || rows < 1 || len(grid) != cols*rows {
+		return "", fmt.Errorf("%w: %d letters for %dx%d grid", ErrInvalidDimensions, len(grid), cols, rows)
+	}
+	grid = strings.ToLower(grid)
+	for i := 0; i < len(grid); i++ {
+		if grid[i] < 'a' || grid[i] > 'z' {
+			return "", fmt.Errorf("%w: %q at square %d", ErrInvalidCharacter, grid[i], i)
+		}
+	}
+	return grid, nil
+}
+
+// DecodePuzzle decodes a token created by EncodePuzzle, and returns the grid
+// and its dimensions. An error is returned if the token is corrupt or does not
+// describe a valid grid.
+func DecodePuzzle(token string) (string, int, int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", 0, 0, errors.New("solver: puzzle token is not valid base64")
+	}
+	cols, n := binary.Uvarint(data)
+	if n <= 0 {
+		return "", 0, 0, errors.New("solver: puzzle token missing columns")
+	}
+	data = data[n:]
+	rows, n := binary.Uvarint(data)
+	if n <= 0 {
+		return "", 0, 0, errors.New("solver: puzzle token missing rows")
+	}
+	data = data[n:]
+
+	if cols < 1 || rows < 1 || cols*rows/rows != cols || cols*rows != uint64(len(data)) {
+		return "", 0, 0, fmt.Errorf("solver: puzzle token: %w", ErrInvalidDimensions)
+	}
+	for _, c := range data {
+		if c < 'a' || c > 'z' {
+			return "", 0, 0, fmt.Errorf("solver: puzzle token: %w", ErrInvalidCharacter)
+		}
+	}
+	return string(data), int(cols), int(rows), nil
+}
+
+// FormatPuzzle returns the given grid and its dimensions as short text that is
+// easy to copy and paste, such as "4x4:qadfetriihkriflv#1a2b". The text ends
+// with a checksum, so that ParsePuzzle detects text that was changed or cut
+// short when shared. Unlike EncodePuzzle, the grid is readable in the text.
+//
+// The Qu tile is given as 'q', the same as for Solve. The grid is checked the
+// same as by EncodePuzzle, and the same errors are returned for a grid that
+// ParsePuzzle would reject.
+func FormatPuzzle(grid string, cols, rows int) (string, error) {
+	grid, err := checkPuzzleGrid(grid, cols, rows)
+	if err != nil {
+		return "", err
+	}
+	body := fmt.Sprintf("%dx%d:%s", cols, rows, grid)
+	return body + "#" + puzzleChecksum(body), nil
+}
+
+// ParsePuzzle parses text created by FormatPuzzle, and returns the grid and
+// its dimensions. Letters are parsed without regard to case, and surrounding
+// space is ignored. An error wrapping ErrChecksum is returned if the checksum
+// does not match the rest of the text. Other errors are returned if the text
+// is not in the form created by FormatPuzzle or does not describe a valid
+// grid.
+func ParsePuzzle(text string) (string, int, int, error) {
+	body, sum, ok := strings.Cut(strings.ToLower(strings.TrimSpace(text)), "#")
+	if !ok {
+		return "", 0, 0, errors.New("solver: puzzle text missing checksum")
+	}
+	if sum != puzzleChecksum(body) {
+		return "", 0, 0, fmt.Errorf("solver: puzzle text: %w", ErrChecksum)
+	}
+	dims, grid, ok := strings.Cut(body, ":")
+	if !ok {
+		return "", 0, 0, errors.New("solver: puzzle text missing dimensions")
+	}
+	colStr, rowStr, _ := strings.Cut(dims, "x")
+	cols, err := strconv.Atoi(colStr)
+	if err != nil || cols < 1 {
+		return "", 0, 0, fmt.Errorf("solver: puzzle text: %w", ErrInvalidDimensions)
+	}
+	rows, err := strconv.Atoi(rowStr)
+	if err != nil || rows < 1 {
+		return "", 0, 0, fmt.Errorf("solver: puzzle text: %w", ErrInvalidDimensions)
+	}
+	if err = checkGridSize(grid, cols*rows); err != nil {
+		return "", 0, 0, fmt.Errorf("solver: puzzle text: %w", err)
+	}
+	for i := 0; i < len(grid); i++ {
+		if c := grid[i]; c < 'a' || c > 'z' {
+			return "", 0, 0, fmt.Errorf("solver: puzzle text: %w", ErrInvalidCharacter)
+		}
+	}
+	return grid, cols, rows, nil
+}
+
+// puzzleChecksum returns the checksum of puzzle text as four hex digits.
+func puzzleChecksum(body string) string {
+	return fmt.Sprintf("%04x", crc32.ChecksumIEEE([]byte(body))&0xffff)
+}
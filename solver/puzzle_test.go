@@ -0,0 +1,163 @@
+package solver
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEncodePuzzle(t *testing.T) {
+	grids := []struct {
+		grid       string
+		cols, rows int
+	}{
+		{"qadfetriihkriflv", 4, 4},
+		{"QADFETRIIHKRIFLVCTOR", 4, 5},
+		{"a", 1, 1},
+		{"abcdefg", 7, 1},
+	}
+	for _, g := range grids {
+		token, err := EncodePuzzle(g.grid, g.cols, g.rows)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.ContainsAny(token, "+/=") {
+			t.Error("token is not URL-safe:", token)
+		}
+		grid, cols, rows, err := DecodePuzzle(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if grid != strings.ToLower(g.grid) {
+			t.Errorf("expected grid %q, got %q", strings.ToLower(g.grid), grid)
+		}
+		if cols != g.cols || rows != g.rows {
+			t.Errorf("expected %dx%d, got %dx%d", g.cols, g.rows, cols, rows)
+		}
+	}
+
+	// Qu tile is a single cell.
+	token, err := EncodePuzzle("quit", 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid, _, _, err := DecodePuzzle(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if grid != "quit" {
+		t.Fatal("wrong grid:", grid)
+	}
+
+	// A grid that DecodePuzzle would reject is not encoded.
+	if _, err = EncodePuzzle("ab1d", 2, 2); !errors.Is(err, ErrInvalidCharacter) {
+		t.Error("expected ErrInvalidCharacter, got", err)
+	}
+	if _, err = EncodePuzzle("abc", 2, 2); !errors.Is(err, ErrInvalidDimensions) {
+		t.Error("expected ErrInvalidDimensions, got", err)
+	}
+}
+
+func TestDecodePuzzleCorrupt(t *testing.T) {
+	token, err := EncodePuzzle("qadfetriihkriflv", 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A token for a grid with a character that is not a letter, which
+	// EncodePuzzle does not create.
+	badChar := base64.RawURLEncoding.EncodeToString([]byte{2, 2, 'a', 'b', '1', 'd'})
+
+	bad := []string{
+		"",
+		"!!!!",
+		token[:len(token)-2],
+		token + "YQ",
+		token[1:],
+		badChar,
+	}
+	for _, tok := range bad {
+		if _, _, _, err := DecodePuzzle(tok); err == nil {
+			t.Errorf("failed to reject corrupt token %q", tok)
+		}
+	}
+
+	_, _, _, err = DecodePuzzle(badChar)
+	if !errors.Is(err, ErrInvalidCharacter) {
+		t.Error("expected ErrInvalidCharacter, got", err)
+	}
+	_, _, _, err = DecodePuzzle(token[:len(token)-2])
+	if !errors.Is(err, ErrInvalidDimensions) {
+		t.Error("expected ErrInvalidDimensions, got", err)
+	}
+}
+
+func TestFormatPuzzle(t *testing.T) {
+	text, err := FormatPuzzle("QadfetriihkriflvctoR", 4, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(text, "4x5:qadfetriihkriflvctor#") || len(text) != 29 {
+		t.Fatal("wrong puzzle text:", text)
+	}
+	for _, in := range []string{text, strings.ToUpper(text), " " + text + "\n"} {
+		grid, cols, rows, err := ParsePuzzle(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if grid != "qadfetriihkriflvctor" || cols != 4 || rows != 5 {
+			t.Fatalf("wrong puzzle from %q: %s %dx%d", in, grid, cols, rows)
+		}
+	}
+
+	// A grid that ParsePuzzle would reject is not formatted.
+	if _, err = FormatPuzzle("ab1d", 2, 2); !errors.Is(err, ErrInvalidCharacter) {
+		t.Error("expected ErrInvalidCharacter, got", err)
+	}
+	if _, err = FormatPuzzle("abc", 2, 2); !errors.Is(err, ErrInvalidDimensions) {
+		t.Error("expected ErrInvalidDimensions, got", err)
+	}
+}
+
+func TestParsePuzzleCorrupt(t *testing.T) {
+	text, err := FormatPuzzle("qadfetriihkriflv", 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	corrupt := []string{
+		strings.Replace(text, "qad", "qed", 1),
+		strings.Replace(text, "4x4", "4x3", 1),
+		text[:len(text)-1],
+		strings.Replace(text, "riih", "rih", 1),
+	}
+	for _, in := range corrupt {
+		if _, _, _, err := ParsePuzzle(in); !errors.Is(err, ErrChecksum) {
+			t.Errorf("expected ErrChecksum for %q, got %v", in, err)
+		}
+	}
+
+	if _, _, _, err := ParsePuzzle("4x4:qadfetriihkriflv"); err == nil {
+		t.Error("failed to reject text without checksum")
+	}
+
+	// Text with a valid checksum that does not describe a valid grid.
+	withSum := func(body string) string {
+		return body + "#" + puzzleChecksum(body)
+	}
+	_, _, _, err = ParsePuzzle(withSum("4x4:qadfetriihkrifl"))
+	if !errors.Is(err, ErrGridTooShort) {
+		t.Error("expected ErrGridTooShort, got", err)
+	}
+	_, _, _, err = ParsePuzzle(withSum("0x4:"))
+	if !errors.Is(err, ErrInvalidDimensions) {
+		t.Error("expected ErrInvalidDimensions, got", err)
+	}
+	_, _, _, err = ParsePuzzle(withSum("2x2:ab1d"))
+	if !errors.Is(err, ErrInvalidCharacter) {
+		t.Error("expected ErrInvalidCharacter, got", err)
+	}
+	if _, _, _, err = ParsePuzzle(withSum("abcd")); err == nil {
+		t.Error("failed to reject text without dimensions")
+	}
+}
@@ -0,0 +1,61 @@
+package solver
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestRestrictPrefixes(t *testing.T) {
+	for _, backend := range []Backend{RadixTree, CompactTrie} {
+		s, err := New(4, 5, "", WithBackend(backend), WithReverseIndex(true))
+		if err != nil {
+			t.Fatal(err)
+		}
+		grid := "qadfetriihkriflvctor"
+		all, err := s.Solve(grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r := s.RestrictPrefixes("hea", "Qu")
+		words, err := r.Solve(grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect := filterWords(slices.Clone(all), func(w string) bool {
+			return len(w) >= 3 && (w[:3] == "hea" || w[:2] == "qu")
+		})
+		if len(expect) == 0 {
+			t.Fatal("test grid has no words with prefixes")
+		}
+		if len(expect) == len(all) {
+			t.Fatal("test grid has only words with prefixes")
+		}
+		if !reflect.DeepEqual(words, expect) {
+			t.Fatalf("backend %d: expected %v, got %v", backend, expect, words)
+		}
+		if r.WordCount() >= s.WordCount() {
+			t.Fatal("restricted dictionary is not smaller")
+		}
+
+		words, err = r.SolveEndingAt(grid, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, w := range words {
+			if w[:3] != "hea" && w[:2] != "qu" {
+				t.Fatal("reverse index has word without prefix:", w)
+			}
+		}
+
+		// The full Solver is not changed.
+		if words, _ = s.Solve(grid); !reflect.DeepEqual(words, all) {
+			t.Fatal("full solver changed")
+		}
+
+		if r = s.RestrictPrefixes(); r.WordCount() != 0 {
+			t.Fatal("expected no words without prefixes")
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package solver
+
+import (
+	"fmt"
+
+	"github.com/gammazero/radixtree"
+)
+
+// SolveEndingAt finds the words in the given Boggle grid that can be traced
+// along a path that ends at square sq. The returned words are sorted.
+//
+// If the Solver was created using WithReverseIndex, then the words are found
+// by searching backward from sq using the reverse index. Otherwise, the whole
+// board is searched and only words with paths ending at sq are kept, which
+// takes as long as Solve.
+func (s Solver) SolveEndingAt(grid string, sq int) ([]string, error) {
+	board, err := s.board(grid)
+	if err != nil {
+		return nil, err
+	}
+	if sq < 0 || sq >= len(board) || (s.mask != nil && !s.mask[sq]) {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidSquare, sq)
+	}
+
+	var words []string
+	sc := &Scratch{}
+	if s.rev != nil {
+		// Searching from sq with the reverse index follows each path
+		// backward, so every word found ends at sq.
+		r := s
+		r.dict = s.rev
+		r.search(sc, board, sq, func(word string, _ []int) bool {
+			if out, ok := s.output(word); ok {
+				words = append(words, out)
+			}
+			return true
+		})
+		return uniqueSortedWords(words), sc.nodeErr()
+	}
+
+	found := func(word string, path []int) bool {
+		if path[len(path)-1] == sq {
+			if out, ok := s.output(word); ok {
+				words = append(words, out)
+			}
+		}
+		return true
+	}
+	for initSq := 0; initSq < len(board); initSq++ {
+		if !s.search(sc, board, initSq, found) {
+			break
+		}
+	}
+	return uniqueSortedWords(words), sc.nodeErr()
+}
+
+// reverseTree returns a tree holding the same words as the given tree, with
+// each key reversed.
+func reverseTree(tree *radixtree.Tree) *radixtree.Tree {
+	rev := radixtree.New()
+	tree.Walk("", func(key string, value any) bool {
+		rev.Put(reverseKey(key), value)
+		return false
+	})
+	return rev
+}
+
+// reverseKey returns the key with its letters in reverse order.
+func reverseKey(key string) string {
+	b := make([]byte, len(key))
+	for i := 0; i < len(key); i++ {
+		b[len(key)-1-i] = key[i]
+	}
+	return string(b)
+}
@@ -0,0 +1,64 @@
+package solver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSolveEndingAt(t *testing.T) {
+	wordsPath := writeWords(t, "cat", "act", "tac", "tea", "eat", "ate", "quit", "quite")
+	// +---+---+---+
+	// | C | A | T |
+	// +---+---+---+
+	// | Qu| I | E |
+	// +---+---+---+
+	const grid = "catqie"
+	expect := map[int][]string{
+		0: {"tac"},
+		1: {"tea"},
+		2: {"cat", "eat", "quit"},
+		4: nil,
+		5: {"ate", "quite"},
+	}
+	for _, reverse := range []bool{false, true} {
+		s, err := New(3, 2, wordsPath, WithReverseIndex(reverse))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for sq, words := range expect {
+			found, err := s.SolveEndingAt(grid, sq)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(found) != len(words) || (len(words) != 0 && !reflect.DeepEqual(found, words)) {
+				t.Errorf("reverse=%t: expected %v ending at %d, got %v", reverse, words, sq, found)
+			}
+		}
+		if _, err = s.SolveEndingAt(grid, 6); !errors.Is(err, ErrInvalidSquare) {
+			t.Error("expected ErrInvalidSquare, got", err)
+		}
+
+		// Changes to the dictionary also change the reverse index.
+		if _, err = s.AddWord("tic"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s.RemoveWord("cat"); err != nil {
+			t.Fatal(err)
+		}
+		found, err := s.SolveEndingAt(grid, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(found, []string{"eat", "quit"}) {
+			t.Errorf("reverse=%t: wrong words after update: %v", reverse, found)
+		}
+		found, err = s.SolveEndingAt(grid, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(found, []string{"tac", "tic"}) {
+			t.Errorf("reverse=%t: wrong words after update: %v", reverse, found)
+		}
+	}
+}
@@ -0,0 +1,406 @@
+package solver
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Score returns the points scored for a word using the scoring of the
+// physical game: 3 or 4 letters score 1 point, 5 letters 2 points, 6 letters
+// 3 points, 7 letters 5 points, and 8 or more letters 11 points. Words shorter
+// than 3 letters score nothing.
+//
+// A word is scored by the number of letters as spelled, so a word using the
+// Qu tile counts both letters of the tile.
+func Score(word string) int {
+	switch n := len(word); {
+	case n < 3:
+		return 0
+	case n <= 4:
+		return 1
+	case n == 5:
+		return 2
+	case n == 6:
+		return 3
+	case n == 7:
+		return 5
+	}
+	return 11
+}
+
+// TotalScore returns the total points scored for the words, such as all the
+// solutions of a board, with each word scored using Score.
+func TotalScore(words []string) int {
+	var total int
+	for _, w := range words {
+		total += Score(w)
+	}
+	return total
+}
+
+// ScoredWord is a word found on a board with the points it scores.
+type ScoredWord struct {
+	// Word is the word found.
+	Word string
+	// Score is the points scored for the word.
+	Score int
+}
+
+// SolveScored generates all solutions for the given Boggle grid, the same as
+// Solve, and returns each word with its score, in the same order. Words are
+// scored using Score, unless the Solver was created using WithScoreFunc, so a
+// word using the Qu tile counts both letters of the tile: "queen" has five
+// letters and scores 2 points.
+func (s Solver) SolveScored(grid string) ([]ScoredWord, error) {
+	words, err := s.Solve(grid)
+	if err != nil {
+		return nil, err
+	}
+	scored := make([]ScoredWord, len(words))
+	for i, w := range words {
+		scored[i] = ScoredWord{
+			Word:  w,
+			Score: s.scoreWord(w, len(normalizeWord(w, s.cfg.caseSensitive))),
+		}
+	}
+	return scored, nil
+}
+
+// ScoreFunc returns the points scored for a word. The word is spelled in full,
+// in the Solver's word case, and pathLen is the number of squares in the path
+// that spells the word. The Qu tile is one square, so pathLen is one less than
+// the length of a word spelled using the Qu tile.
+type ScoreFunc func(word string, pathLen int) int
+
+// scoreWord returns the points scored for a word, using the Solver's ScoreFunc
+// if one is set, or using Score otherwise.
+func (s Solver) scoreWord(word string, pathLen int) int {
+	if s.cfg.scoreFn == nil {
+		return Score(word)
+	}
+	return s.cfg.scoreFn(word, pathLen)
+}
+
+// Leaderboard solves the grid and returns a printable table of the top
+// scoring words, with columns for rank, word, length, and score. Words are
+// ordered by descending score, and alphabetically among words with the same
+// score. At most top words are listed, or all words if top is not positive.
+// Words are scored using Score, unless the Solver was created using
+// WithScoreFunc.
+func (s Solver) Leaderboard(grid string, top int) (string, error) {
+	words, err := s.Solve(grid)
+	if err != nil {
+		return "", err
+	}
+	scores := make(map[string]int, len(words))
+	for _, w := range words {
+		scores[w] = s.scoreWord(w, len(NormalizeWord(w)))
+	}
+	sort.Slice(words, func(i, j int) bool {
+		si, sj := scores[words[i]], scores[words[j]]
+		if si != sj {
+			return si > sj
+		}
+		return words[i] < words[j]
+	})
+	if top > 0 && top < len(words) {
+		words = words[:top]
+	}
+
+	rankWidth := max(len("Rank"), len(strconv.Itoa(len(words))))
+	wordWidth := len("Word")
+	for _, w := range words {
+		wordWidth = max(wordWidth, len(w))
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%*s  %-*s  Length  Score\n", rankWidth, "Rank", wordWidth, "Word")
+	for i, w := range words {
+		fmt.Fprintf(&b, "%*d  %-*s  %6d  %5d\n", rankWidth, i+1, wordWidth, w, len(w), scores[w])
+	}
+	return b.String(), nil
+}
+
+// ScoreTier is the number of words that score the same points.
+type ScoreTier struct {
+	// Points is the points scored by each word in the tier.
+	Points int
+	// Words is the number of words in the tier.
+	Words int
+}
+
+// ScoreTiers groups words, such as the solutions of a board, by the points
+// they score, and returns the number of words in each group, from the lowest
+// to the highest points. This gives a quick measure of a board without
+// listing every word. Words are scored using Score, unless the Solver was
+// created using WithScoreFunc.
+func (s Solver) ScoreTiers(words []string) []ScoreTier {
+	counts := make(map[int]int)
+	for _, w := range words {
+		counts[s.scoreWord(w, len(normalizeWord(w, s.cfg.caseSensitive)))]++
+	}
+	tiers := make([]ScoreTier, 0, len(counts))
+	for points, n := range counts {
+		tiers = append(tiers, ScoreTier{Points: points, Words: n})
+	}
+	slices.SortFunc(tiers, func(a, b ScoreTier) int { return a.Points - b.Points })
+	return tiers
+}
+
+// FormatScoreTiers returns a one line summary of score tiers, such as
+// "1pt: 40 words, 2pt: 12, 3pt: 5", without a trailing newline.
+func FormatScoreTiers(tiers []ScoreTier) string {
+	var b strings.Builder
+	for i, t := range tiers {
+		if i != 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%dpt: %d", t.Points, t.Words)
+		if i == 0 {
+			b.WriteString(" words")
+		}
+	}
+	return b.String()
+}
+
+// BestWord returns the highest scoring word found in the given Boggle grid,
+// along with a path that spells it, and true if any word is found. Words that
+// score the same are ordered alphabetically and the first is returned. If the
+// word can be traced more than one way, then the path returned is the first
+// when paths are compared square by square. Words are scored using Score,
+// unless the Solver was created using WithScoreFunc.
+func (s Solver) BestWord(grid string) (WordPath, bool, error) {
+	play, ok, err := s.BestPlay(grid)
+	return play.WordPath, ok, err
+}
+
+// Play is a word to play on a board, with the path to trace it and the points
+// it scores.
+type Play struct {
+	WordPath
+	// Score is the points scored for the word.
+	Score int
+}
+
+// BestPlay returns the highest scoring play on the given Boggle grid, and true
+// if any word is found. The play gives the word, the squares of the path that
+// spells it, the direction of each step along the path, and the word's score,
+// for showing a player the best move. Words are scored using Score, unless the
+// Solver was created using WithScoreFunc.
+//
+// Ties are broken in order: the highest score, then the alphabetically first
+// word, then, if the word can be traced more than one way, the first path when
+// paths are compared square by square. So the same play is always returned for
+// the same board.
+func (s Solver) BestPlay(grid string) (Play, bool, error) {
+	board, err := s.board(grid)
+	if err != nil {
+		return Play{}, false, err
+	}
+
+	var best string
+	var bestPath []int
+	var bestScore int
+	var haveBest bool
+	found := func(w string, path []int) bool {
+		word, ok := s.output(w)
+		if !ok {
+			return true
+		}
+		score := s.scoreWord(word, len(path))
+		if haveBest {
+			switch {
+			case score < bestScore:
+				return true
+			case score == bestScore:
+				if word > best || (word == best && slices.Compare(path, bestPath) >= 0) {
+					return true
+				}
+			}
+		}
+		best, bestScore, haveBest = word, score, true
+		bestPath = append(bestPath[:0], path...)
+		return true
+	}
+	sc := &Scratch{}
+	for initSq := 0; initSq < len(board); initSq++ {
+		if !s.search(sc, board, initSq, found) {
+			break
+		}
+	}
+	if !haveBest {
+		return Play{}, false, sc.nodeErr()
+	}
+	return Play{
+		WordPath: WordPath{
+			Word:       best,
+			Path:       bestPath,
+			Directions: s.DirectionsFor(bestPath),
+		},
+		Score: bestScore,
+	}, true, sc.nodeErr()
+}
+
+// LetterPoints holds the points for each letter, from a to z, used to score
+// words with SolveMultiplied. These are the letter values of Scrabble.
+var LetterPoints = [26]int{
+	1, 3, 3, 2, 1, 4, 2, 4, 1, 8, 5, 1, 3,
+	1, 1, 3, 10, 1, 1, 1, 1, 4, 4, 8, 4, 10,
+}
+
+// Multipliers holds the score multipliers of bonus squares on a board, like
+// the double letter and triple word squares of Scrabble. Squares that are not
+// in a map have a multiplier of 1.
+type Multipliers struct {
+	// Letter maps a square to the multiplier of the points of the letter on
+	// the square.
+	Letter map[int]int
+	// Word maps a square to the multiplier of the score of each word whose
+	// path uses the square.
+	Word map[int]int
+}
+
+// MultipliedWord is a word found on a board, scored using Multipliers.
+type MultipliedWord struct {
+	// Word is the word found.
+	Word string
+	// Path is the squares of the highest scoring path that spells the word.
+	Path []int
+	// Score is the points scored for the word along the path.
+	Score int
+}
+
+// SolveMultiplied generates all solutions for the given Boggle grid, and
+// scores each word along its path using the given multipliers. A word scores
+// the sum of the points of the letters on its path, given by LetterPoints and
+// multiplied by the letter multipliers of their squares, and then multiplied
+// by the word multiplier of each square on the path. The Qu tile scores the
+// points of both q and u.
+//
+// If a word can be traced more than one way, it is scored along its highest
+// scoring path, and the first such path when compared square by square. The
+// results are ordered by descending score, and alphabetically among words with
+// the same score. An error wrapping ErrInvalidSquare is returned if a
+// multiplier is given for a square that is not on the board.
+func (s Solver) SolveMultiplied(grid string, m Multipliers) ([]MultipliedWord, error) {
+	board, err := s.board(grid)
+	if err != nil {
+		return nil, err
+	}
+	for _, mults := range []map[int]int{m.Letter, m.Word} {
+		for sq := range mults {
+			if sq < 0 || sq >= len(board) {
+				return nil, fmt.Errorf("%w: multiplier square %d", ErrInvalidSquare, sq)
+			}
+		}
+	}
+
+	best := make(map[string]*MultipliedWord)
+	found := func(word string, path []int) bool {
+		out, ok := s.output(word)
+		if !ok {
+			return true
+		}
+		score := s.pathScore(board, path, m)
+		mw, ok := best[out]
+		if !ok {
+			mw = &MultipliedWord{Word: out}
+			best[out] = mw
+		} else if score < mw.Score || (score == mw.Score && slices.Compare(path, mw.Path) >= 0) {
+			return true
+		}
+		mw.Score = score
+		mw.Path = append(mw.Path[:0], path...)
+		return true
+	}
+	sc := &Scratch{}
+	for initSq := 0; initSq < len(board); initSq++ {
+		if !s.search(sc, board, initSq, found) {
+			break
+		}
+	}
+
+	words := make([]MultipliedWord, 0, len(best))
+	for _, mw := range best {
+		words = append(words, *mw)
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if words[i].Score != words[j].Score {
+			return words[i].Score > words[j].Score
+		}
+		return words[i].Word < words[j].Word
+	})
+	return words, sc.nodeErr()
+}
+
+// pathScore returns the points scored for the letters along the path, with
+// the given multipliers applied.
+func (s Solver) pathScore(board string, path []int, m Multipliers) int {
+	var score int
+	wordMult := 1
+	for _, sq := range path {
+		c := board[sq] | 0x20 // lower case
+		points := LetterPoints[c-'a']
+		if c == 'q' {
+			points += LetterPoints['u'-'a']
+		}
+		if mult, ok := m.Letter[sq]; ok {
+			points *= mult
+		}
+		score += points
+		if mult, ok := m.Word[sq]; ok {
+			wordMult *= mult
+		}
+	}
+	return score * wordMult
+}
+
+// SolveUntilScore generates solutions for the given Boggle grid, the same as
+// Solve, but stops searching once the words found so far score a total of at
+// least target points. This saves work when only confirming that a board is
+// worth enough points. The words found so far are returned, and the returned
+// bool is true if they reach the target. If the target is not reached, then
+// all words on the board are returned. A target that is not positive is
+// reached without searching.
+//
+// Each distinct word is counted once, and words are scored the same as for
+// BestWord. Since the search stops as soon as the target is reached, the
+// total only shows that the board is worth at least the target, and is not the
+// value of the whole board.
+func (s Solver) SolveUntilScore(grid string, target int) ([]string, bool, error) {
+	board, err := s.board(grid)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var words []string
+	seen := make(map[string]struct{})
+	var total int
+	found := func(w string, path []int) bool {
+		word, ok := s.output(w)
+		if !ok {
+			return true
+		}
+		if _, ok = seen[word]; ok {
+			return true
+		}
+		seen[word] = struct{}{}
+		words = append(words, word)
+		total += s.scoreWord(word, len(path))
+		return total < target
+	}
+	sc := &Scratch{}
+	if target > 0 {
+		for initSq := 0; initSq < len(board); initSq++ {
+			if !s.search(sc, board, initSq, found) {
+				break
+			}
+		}
+	}
+	if !s.cfg.unsorted {
+		slices.Sort(words)
+	}
+	return words, total >= target, sc.nodeErr()
+}
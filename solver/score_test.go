@@ -0,0 +1,414 @@
+package solver
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestScore(t *testing.T) {
+	for word, expect := range map[string]int{
+		"":                 0,
+		"at":               0,
+		"cat":              1,
+		"cats":             1,
+		"queen":            2,
+		"quarte":           3,
+		"reading":          5,
+		"quartile":         11,
+		"abcdefghijklmnop": 11,
+	} {
+		if score := Score(word); score != expect {
+			t.Errorf("expected score %d for %q, got %d", expect, word, score)
+		}
+	}
+}
+
+func TestScoreTiers(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	words := []string{"cat", "tea", "quit", "heart", "quarte", "earth", "fourteen", "at"}
+	tiers := s.ScoreTiers(words)
+	expect := []ScoreTier{
+		{Points: 0, Words: 1},
+		{Points: 1, Words: 3},
+		{Points: 2, Words: 2},
+		{Points: 3, Words: 1},
+		{Points: 11, Words: 1},
+	}
+	if !reflect.DeepEqual(tiers, expect) {
+		t.Fatalf("expected %v, got %v", expect, tiers)
+	}
+	out := FormatScoreTiers(tiers[1:])
+	if out != "1pt: 3 words, 2pt: 2, 3pt: 1, 11pt: 1" {
+		t.Fatal("wrong format:", out)
+	}
+	if out = FormatScoreTiers(nil); out != "" {
+		t.Fatal("expected empty string, got", out)
+	}
+
+	// Words are scored using the Solver's ScoreFunc.
+	s, err = New(4, 5, "", WithScoreFunc(func(word string, pathLen int) int { return pathLen }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tiers = s.ScoreTiers([]string{"quit", "tea", "cats"})
+	expect = []ScoreTier{{Points: 3, Words: 2}, {Points: 4, Words: 1}}
+	if !reflect.DeepEqual(tiers, expect) {
+		t.Fatalf("expected %v, got %v", expect, tiers)
+	}
+}
+
+func TestSolveScored(t *testing.T) {
+	s, err := New(3, 2, writeWords(t, "queen", "quit", "tea", "teen"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// +---+---+---+
+	// | Qu| E | T |
+	// +---+---+---+
+	// | N | E | A |
+	// +---+---+---+
+	scored, err := s.SolveScored("qetnea")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []ScoredWord{
+		{Word: "queen", Score: 2},
+		{Word: "tea", Score: 1},
+		{Word: "teen", Score: 1},
+	}
+	if !reflect.DeepEqual(scored, expect) {
+		t.Fatalf("expected %v, got %v", expect, scored)
+	}
+
+	words, err := s.Solve("qetnea")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total := TotalScore(words); total != 4 {
+		t.Fatal("wrong total score:", total)
+	}
+	if total := TotalScore(nil); total != 0 {
+		t.Fatal("wrong total score for no words:", total)
+	}
+
+	if _, err = s.SolveScored("qet"); !errors.Is(err, ErrGridTooShort) {
+		t.Fatal("expected ErrGridTooShort, got", err)
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflvctor"
+	table, err := s.Leaderboard(grid, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := "Rank  Word    Length  Score\n" +
+		"   1  quarte       6      3\n" +
+		"   2  earth        5      2\n" +
+		"   3  ethic        5      2\n" +
+		"   4  firth        5      2\n" +
+		"   5  heard        5      2\n"
+	if table != expect {
+		t.Error("did not get expected leaderboard:\n" + table)
+	}
+
+	table, err = s.Leaderboard(grid, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(table, "\n"); lines != 81 {
+		t.Error("expected header and 80 words, got lines:", lines)
+	}
+
+	if _, err = s.Leaderboard("abc", 1); !errors.Is(err, ErrGridTooShort) {
+		t.Error("expected ErrGridTooShort, got", err)
+	}
+}
+
+func TestScoreFunc(t *testing.T) {
+	pathLens := make(map[string]int)
+	fullBoard := func(word string, pathLen int) int {
+		pathLens[word] = pathLen
+		if pathLen == 4 {
+			return Score(word) + 10
+		}
+		return Score(word)
+	}
+	wordsPath := writeWords(t, "cat", "act", "acts", "cast", "scat", "quit")
+	s, err := New(2, 2, wordsPath, WithScoreFunc(fullBoard))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// +---+---+
+	// | C | A |
+	// +---+---+
+	// | T | S |
+	// +---+---+
+	table, err := s.Leaderboard("cats", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := "Rank  Word  Length  Score\n" +
+		"   1  acts       4     11\n" +
+		"   2  cast       4     11\n" +
+		"   3  scat       4     11\n" +
+		"   4  act        3      1\n" +
+		"   5  cat        3      1\n"
+	if table != expect {
+		t.Error("did not get expected leaderboard:\n" + table)
+	}
+
+	best, ok, err := s.BestWord("cats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || best.Word != "acts" {
+		t.Fatal("expected best word acts, got", best.Word)
+	}
+
+	// The Qu tile is one square of the path.
+	// +---+---+
+	// | Qu| I |
+	// +---+---+
+	// | T | S |
+	// +---+---+
+	best, ok, err = s.BestWord("qits")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || best.Word != "quit" {
+		t.Fatal("expected best word quit, got", best.Word)
+	}
+	if pathLens["quit"] != 3 {
+		t.Fatal("wrong path lengths for qu words:", pathLens)
+	}
+}
+
+func TestBestPlay(t *testing.T) {
+	s, err := New(4, 4, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// +---+---+---+---+
+	// | Qu| A | Z | W |
+	// +---+---+---+---+
+	// | S | X | E | D |
+	// +---+---+---+---+
+	// | C | R | F | V |
+	// +---+---+---+---+
+	// | T | G | B | Y |
+	// +---+---+---+---+
+	grid := "qazwsxedcrfvtgby"
+	play, ok, err := s.BestPlay(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("expected a play")
+	}
+	// "derby" and "screw" both score 2, and "derby" is first.
+	expect := Play{
+		WordPath: WordPath{
+			Word:       "derby",
+			Path:       []int{7, 6, 9, 14, 15},
+			Directions: []string{"W", "SW", "SE", "E"},
+		},
+		Score: 2,
+	}
+	if !reflect.DeepEqual(play, expect) {
+		t.Fatalf("expected %+v, got %+v", expect, play)
+	}
+	path, ok, err := s.FindPath(grid, play.Word)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || !reflect.DeepEqual(path, play.Path) {
+		t.Fatal("play path does not spell word:", path)
+	}
+
+	play, ok, err = s.BestPlay("xxxxxxxxxxxxxxxx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok || !reflect.DeepEqual(play, Play{}) {
+		t.Fatal("expected no play, got", play)
+	}
+	if _, _, err = s.BestPlay("abc"); !errors.Is(err, ErrGridTooShort) {
+		t.Fatal("expected ErrGridTooShort, got", err)
+	}
+
+	// A play is found even if every word scores less than zero.
+	neg, err := New(3, 1, writeWords(t, "cat"), WithScoreFunc(func(string, int) int {
+		return -3
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	play, ok, err = neg.BestPlay("cat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || play.Word != "cat" || play.Score != -3 {
+		t.Fatalf("expected cat scoring -3, got %+v", play)
+	}
+}
+
+func TestBestWord(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflvctor"
+	best, ok, err := s.BestWord(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("expected a word")
+	}
+	if best.Word != "quarte" {
+		t.Fatal("wrong best word:", best.Word)
+	}
+	// Qu A R T E
+	if !reflect.DeepEqual(best.Path, []int{0, 1, 6, 5, 4}) {
+		t.Fatal("wrong path:", best.Path)
+	}
+	if len(best.Directions) != len(best.Path)-1 {
+		t.Fatal("wrong directions:", best.Directions)
+	}
+
+	// Among words with the same score, the alphabetically first is returned.
+	s, err = New(2, 2, writeWords(t, "tea", "eat", "ate"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	best, ok, err = s.BestWord("teax")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || best.Word != "ate" || !reflect.DeepEqual(best.Path, []int{2, 0, 1}) {
+		t.Fatal("wrong best word:", best)
+	}
+
+	_, ok, err = s.BestWord("xxxx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatal("expected no word")
+	}
+}
+
+func TestSolveMultiplied(t *testing.T) {
+	s, err := New(2, 2, writeWords(t, "cat", "sat", "quit"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// +---+---+
+	// | C | A |
+	// +---+---+
+	// | T | S |
+	// +---+---+
+	grid := "cats"
+	words, err := s.SolveMultiplied(grid, Multipliers{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []MultipliedWord{
+		{Word: "cat", Path: []int{0, 1, 2}, Score: 5},
+		{Word: "sat", Path: []int{3, 1, 2}, Score: 3},
+	}
+	if !reflect.DeepEqual(words, expect) {
+		t.Fatalf("expected %v, got %v", expect, words)
+	}
+
+	// Double letter on S, and triple word on S.
+	words, err = s.SolveMultiplied(grid, Multipliers{
+		Letter: map[int]int{3: 2},
+		Word:   map[int]int{3: 3},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect = []MultipliedWord{
+		{Word: "sat", Path: []int{3, 1, 2}, Score: 12},
+		{Word: "cat", Path: []int{0, 1, 2}, Score: 5},
+	}
+	if !reflect.DeepEqual(words, expect) {
+		t.Fatalf("expected %v, got %v", expect, words)
+	}
+
+	// The Qu tile scores both letters.
+	words, err = s.SolveMultiplied("qitx", Multipliers{Letter: map[int]int{0: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect = []MultipliedWord{{Word: "quit", Path: []int{0, 1, 2}, Score: 24}}
+	if !reflect.DeepEqual(words, expect) {
+		t.Fatalf("expected %v, got %v", expect, words)
+	}
+
+	_, err = s.SolveMultiplied(grid, Multipliers{Word: map[int]int{4: 2}})
+	if !errors.Is(err, ErrInvalidSquare) {
+		t.Fatal("expected ErrInvalidSquare, got", err)
+	}
+}
+
+func TestSolveUntilScore(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflvctor"
+	all, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var total int
+	for _, w := range all {
+		total += Score(w)
+	}
+
+	words, ok, err := s.SolveUntilScore(grid, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("expected low target to be reached")
+	}
+	var score int
+	for _, w := range words {
+		score += Score(w)
+	}
+	if score < 5 || len(words) >= len(all) {
+		t.Fatalf("expected early stop after reaching target, got %d words scoring %d", len(words), score)
+	}
+
+	words, ok, err = s.SolveUntilScore(grid, total+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatal("expected target above board value not to be reached")
+	}
+	if !reflect.DeepEqual(words, all) {
+		t.Fatal("expected all words when target not reached")
+	}
+
+	words, ok, err = s.SolveUntilScore(grid, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || len(words) != 0 {
+		t.Fatal("expected zero target to be reached with no words")
+	}
+}
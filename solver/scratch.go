@@ -0,0 +1,148 @@
+package solver
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gammazero/deque"
+)
+
+// deadlineCheckInterval is the number of search nodes explored between checks
+// of the search deadline.
+const deadlineCheckInterval = 1024
+
+// Scratch holds the buffers used while solving a grid: the search queue, the
+// squares visited along each searched path, and the found words. Passing the
+// same Scratch to SolveWithScratch repeatedly reuses these buffers, so that a
+// tight loop of solves does not allocate new buffers for each solve.
+//
+// The zero value is ready to use, and buffers are allocated by the first solve
+// that uses them. A Scratch must not be used by more than one solve at a time,
+// but may be used with different Solvers. The words returned by
+// SolveWithScratch are stored in the Scratch, and are only valid until the
+// Scratch is used again. Copy the words to keep them longer.
+type Scratch struct {
+	q     *deque.Deque[qNode]
+	paths []int
+	words []string
+	seen  map[string]struct{}
+
+	nodes    int
+	limited  bool
+	deadline time.Time
+	expired  bool
+}
+
+// queue returns the search queue, creating it if needed.
+func (sc *Scratch) queue(boardSize int) *deque.Deque[qNode] {
+	if sc.q == nil {
+		sc.q = deque.New[qNode](boardSize, boardSize)
+	}
+	return sc.q
+}
+
+// resetNodes resets the count of search nodes explored, for a new solve.
+func (sc *Scratch) resetNodes() {
+	sc.nodes = 0
+	sc.limited = false
+	sc.expired = false
+}
+
+// nodeErr returns ErrNodeLimit if the search node limit was reached.
+func (sc *Scratch) nodeErr() error {
+	if sc.limited {
+		return ErrNodeLimit
+	}
+	return nil
+}
+
+// SolveWithScratch generates all solutions for the given Boggle grid, the same
+// as Solve, using the buffers in sc. The returned words are only valid until
+// sc is used again.
+//
+// After the buffers in sc have grown large enough for the boards being solved,
+// the only allocations made while solving are those made internally by the
+// dictionary trie when stepping through it.
+//
+// If the search node limit set by WithNodeLimit is reached, the words found so
+// far are returned along with ErrNodeLimit.
+func (s Solver) SolveWithScratch(grid string, sc *Scratch) ([]string, error) {
+	return s.solveScratch(grid, sc, nil)
+}
+
+// solveScratch generates all solutions for the given Boggle grid using the
+// buffers in sc. If progress is not nil, it is called after searching from
+// each square.
+func (s Solver) solveScratch(grid string, sc *Scratch, progress func(done, total int)) ([]string, error) {
+	board, err := s.board(grid)
+	if err != nil {
+		return nil, err
+	}
+	return s.solveBoard(board, sc, progress)
+}
+
+// solveBoard generates all solutions for a board that has been checked by
+// board, using the buffers in sc.
+func (s Solver) solveBoard(board string, sc *Scratch, progress func(done, total int)) ([]string, error) {
+	if sc.words == nil {
+		sc.words = make([]string, 0, s.resultCapacity())
+	}
+	sc.resetNodes()
+	words := sc.words[:0]
+	found := func(word string, _ []int) bool {
+		if out, ok := s.output(word); ok {
+			words = append(words, out)
+		}
+		return true
+	}
+	if s.cfg.unsorted {
+		// Unsorted results are kept in the order found, so duplicates are
+		// dropped as words are found, using a set, instead of by sorting.
+		if sc.seen == nil {
+			sc.seen = make(map[string]struct{}, s.resultCapacity())
+		} else {
+			clear(sc.seen)
+		}
+		found = func(word string, _ []int) bool {
+			if out, ok := s.output(word); ok {
+				if _, dup := sc.seen[out]; !dup {
+					sc.seen[out] = struct{}{}
+					words = append(words, out)
+				}
+			}
+			return true
+		}
+	}
+	for initSq := 0; initSq < len(board); initSq++ {
+		if !s.search(sc, board, initSq, found) {
+			break
+		}
+		if progress != nil {
+			progress(initSq+1, len(board))
+		}
+	}
+	sc.words = words
+
+	if s.cfg.unsorted {
+		return words, sc.nodeErr()
+	}
+	return uniqueSortedWords(words), sc.nodeErr()
+}
+
+// SolveDeadline generates solutions for the given Boggle grid, the same as
+// Solve, but stops searching when the time limit d has passed, and returns the
+// words found so far. The returned bool is true if the search completed before
+// the time limit, in which case all words are returned.
+//
+// The clock is checked periodically while searching, so the search may run a
+// little longer than d.
+func (s Solver) SolveDeadline(grid string, d time.Duration) ([]string, bool, error) {
+	sc := &Scratch{
+		deadline: time.Now().Add(d),
+	}
+	words, err := s.SolveWithScratch(grid, sc)
+	if err != nil && !errors.Is(err, ErrNodeLimit) {
+		return nil, false, err
+	}
+	return words, err == nil && !sc.expired, err
+}
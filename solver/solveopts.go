@@ -0,0 +1,161 @@
+package solver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// SortMode selects how words returned by SolveOpts are ordered.
+type SortMode int
+
+const (
+	// SortDefault uses the sorting configured for the Solver.
+	SortDefault SortMode = iota
+	// SortAlpha sorts words alphabetically.
+	SortAlpha
+	// SortNone leaves words in an unspecified order.
+	SortNone
+)
+
+// SolveOptions contains options that apply to a single call to SolveOpts.
+//
+// The zero value of each field means that the Solver's own configuration
+// applies, so SolveOpts with a zero SolveOptions returns the same words as
+// Solve. When a field is set, it takes precedence over the Solver's
+// configuration for that call only.
+type SolveOptions struct {
+	// MinLength is the minimum length of words to return. This can only
+	// raise the minimum length of words loaded into the Solver's dictionary.
+	MinLength int
+	// MaxResults is the maximum number of words to return. If more words are
+	// found, then Result.Truncated is set.
+	MaxResults int
+	// Sort selects the order of the returned words.
+	Sort SortMode
+	// RequiredSquares are squares that must all be on the path of a word for
+	// that word to be returned.
+	RequiredSquares []int
+}
+
+// Result holds the words found by solving a grid.
+type Result struct {
+	// Words are the words found in the grid.
+	Words []string `json:"words"`
+	// Truncated is true if not all words found are included in Words.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// SolveResult generates all solutions for the given Boggle grid, the same as
+// Solve, and returns them in a Result. If the search node limit set by
+// WithNodeLimit is reached, then the Result has Truncated set and is returned
+// along with ErrNodeLimit.
+func (s Solver) SolveResult(grid string) (Result, error) {
+	words, err := s.Solve(grid)
+	if err != nil && !errors.Is(err, ErrNodeLimit) {
+		return Result{}, err
+	}
+	if words == nil {
+		words = []string{}
+	}
+	return Result{
+		Words:     words,
+		Truncated: err != nil,
+	}, err
+}
+
+// SolveJSON generates all solutions for the given Boggle grid and returns the
+// JSON encoding of the Result returned by SolveResult.
+func (s Solver) SolveJSON(grid string) ([]byte, error) {
+	result, err := s.SolveResult(grid)
+	if err != nil && !errors.Is(err, ErrNodeLimit) {
+		return nil, err
+	}
+	data, jsonErr := json.Marshal(result)
+	if jsonErr != nil {
+		return nil, jsonErr
+	}
+	return data, err
+}
+
+// SolveSortedBy generates all solutions for the given Boggle grid, the same as
+// Solve, and returns them sorted by the given less function. This allows any
+// order, such as by frequency, by score, or reverse alphabetical. Words are
+// still returned only once, and only their order is given by less.
+//
+// The less function reports whether word a sorts before word b, and must
+// define a strict weak ordering, the same as for sort.Slice. Words that sort
+// the same as each other are left in the order returned by Solve.
+func (s Solver) SolveSortedBy(grid string, less func(a, b string) bool) ([]string, error) {
+	words, err := s.Solve(grid)
+	sort.SliceStable(words, func(i, j int) bool {
+		return less(words[i], words[j])
+	})
+	return words, err
+}
+
+// SolveOpts generates solutions for the given Boggle grid, the same as Solve,
+// using the options given for this call. This avoids creating a separate
+// Solver for each variation of behavior.
+func (s Solver) SolveOpts(grid string, o SolveOptions) (Result, error) {
+	board, err := s.board(grid)
+	if err != nil {
+		return Result{}, err
+	}
+	for _, sq := range o.RequiredSquares {
+		if sq < 0 || sq >= s.BoardSize() {
+			return Result{}, fmt.Errorf("%w: required square %d", ErrInvalidSquare, sq)
+		}
+	}
+
+	words := make([]string, 0, s.resultCapacity())
+	found := func(w string, path []int) bool {
+		word, ok := s.output(w)
+		if ok && len(word) >= o.MinLength && containsAll(path, o.RequiredSquares) {
+			words = append(words, word)
+		}
+		return true
+	}
+	sc := &Scratch{}
+	for initSq := 0; initSq < len(board); initSq++ {
+		if !s.search(sc, board, initSq, found) {
+			break
+		}
+	}
+
+	sorted := !s.cfg.unsorted
+	switch o.Sort {
+	case SortAlpha:
+		sorted = true
+	case SortNone:
+		sorted = false
+	}
+	if sorted {
+		words = uniqueSortedWords(words)
+	} else {
+		words = uniqueWords(words, nil)
+	}
+
+	var result Result
+	if o.MaxResults > 0 && len(words) > o.MaxResults {
+		words = words[:o.MaxResults]
+		result.Truncated = true
+	}
+	result.Words = words
+	return result, sc.nodeErr()
+}
+
+// containsAll returns true if path contains all of the given squares.
+func containsAll(path, squares []int) bool {
+SqLoop:
+	for _, sq := range squares {
+		for _, p := range path {
+			if p == sq {
+				continue SqLoop
+			}
+		}
+		return false
+	}
+	return true
+}
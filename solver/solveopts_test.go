@@ -0,0 +1,159 @@
+package solver
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSolveOpts(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflvctor"
+	expect, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := s.SolveOpts(grid, SolveOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result.Words, expect) || result.Truncated {
+		t.Fatal("default options did not reproduce Solve")
+	}
+
+	result, err = s.SolveOpts(grid, SolveOptions{MinLength: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expectLong []string
+	for _, w := range expect {
+		if len(w) >= 5 {
+			expectLong = append(expectLong, w)
+		}
+	}
+	if !reflect.DeepEqual(result.Words, expectLong) {
+		t.Fatal("wrong words for minimum length:", result.Words)
+	}
+
+	result, err = s.SolveOpts(grid, SolveOptions{MaxResults: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result.Words, expect[:10]) || !result.Truncated {
+		t.Fatal("results not truncated to max")
+	}
+
+	result, err = s.SolveOpts(grid, SolveOptions{Sort: SortNone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(uniqueSortedWords(result.Words), expect) {
+		t.Fatal("unsorted results do not match sorted results")
+	}
+
+	// All words using the Qu tile.
+	result, err = s.SolveOpts(grid, SolveOptions{RequiredSquares: []int{0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range result.Words {
+		if w[:2] != "qu" {
+			t.Fatal("word does not use required square:", w)
+		}
+	}
+	if len(result.Words) == 0 {
+		t.Fatal("expected words using required square")
+	}
+
+	if _, err = s.SolveOpts(grid, SolveOptions{RequiredSquares: []int{20}}); !errors.Is(err, ErrInvalidSquare) {
+		t.Fatal("failed to catch invalid required square")
+	}
+
+	// Call-level sort overrides solver-level setting.
+	s, err = New(4, 5, "", WithSortResults(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err = s.SolveOpts(grid, SolveOptions{Sort: SortAlpha})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result.Words, expect) {
+		t.Fatal("call-level sort did not override solver setting")
+	}
+}
+
+func TestSolveJSON(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflvctor"
+	expect, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := s.SolveJSON(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result Result
+	if err = json.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result.Words, expect) || result.Truncated {
+		t.Fatal("wrong result from JSON:", result)
+	}
+
+	data, err = s.SolveJSON("xxxxxxxxxxxxxxxxxxxx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"words":[]}` {
+		t.Fatal("wrong JSON for board without words:", string(data))
+	}
+
+	if _, err = s.SolveJSON("abc"); !errors.Is(err, ErrGridTooShort) {
+		t.Fatal("expected ErrGridTooShort, got", err)
+	}
+}
+
+func TestSolveSortedBy(t *testing.T) {
+	s, err := New(3, 3, writeWords(t, "cat", "cats", "tea", "eat", "seat"), WithSortResults(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// +---+---+---+
+	// | C | A | T |
+	// +---+---+---+
+	// | X | E | S |
+	// +---+---+---+
+	// | X | X | X |
+	// +---+---+---+
+	grid := "catxesxxx"
+	// Longest words first, and reverse alphabetical among words of the same
+	// length.
+	words, err := s.SolveSortedBy(grid, func(a, b string) bool {
+		if len(a) != len(b) {
+			return len(a) > len(b)
+		}
+		return a > b
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []string{"seat", "cats", "tea", "eat", "cat"}
+	if !reflect.DeepEqual(words, expect) {
+		t.Fatalf("expected %v, got %v", expect, words)
+	}
+
+	if _, err = s.SolveSortedBy("abc", func(a, b string) bool { return a < b }); !errors.Is(err, ErrGridTooShort) {
+		t.Fatal("expected ErrGridTooShort, got", err)
+	}
+}
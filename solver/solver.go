@@ -4,29 +4,34 @@ import (
 	"bufio"
 	"compress/gzip"
 	"embed"
-	"errors"
 	"fmt"
 	"io"
 	"os"
-	"sort"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/gammazero/deque"
 	"github.com/gammazero/radixtree"
 )
 
 const defaultWords = "boggle_words.txt.gz"
 
+// DefaultMinWordLength is the minimum number of letters in a dictionary word
+// that a Solver searches for.
+const DefaultMinWordLength = 3
+
 //go:embed boggle_words.txt.gz
 var wordsFile embed.FS
 
-var adj = make([]int, 0, 8)
-
 // qNode is a element of the queue constructed while searching word paths.
+// The squares seen on the path to the node are stored in Scratch.paths.
 type qNode struct {
 	parentSquare int
-	parentTrie   *radixtree.Stepper
-	seen         []int
+	parentTrie   stepper
+	seenStart    int
+	seenLen      int
 }
 
 // Solver implements the algorithm to find words in the Boggle grid.
@@ -37,7 +42,21 @@ type qNode struct {
 type Solver struct {
 	cols int
 	rows int
-	rt   *radixtree.Tree
+	dict dictionary
+	// rev is the reverse index, or nil if not enabled.
+	rev dictionary
+	// freq holds the frequency of each word, or is nil if frequencies were
+	// not loaded.
+	freq map[string]int
+	cfg  config
+	adj  [][]int
+	mask []bool
+
+	minLen int
+	maxLen int
+	// mu guards changes to the dictionary, and is shared by copies of the
+	// Solver since they share the dictionary.
+	mu *sync.RWMutex
 }
 
 // New creates and initializes a Solver instance.
@@ -48,24 +67,88 @@ type Solver struct {
 //
 // New takes the board dimensions xlen and ylen, a an optional file which can
 // be gz compressed. If no file is specified, then the embedded words list is
-// used.
+// used. Any options given are applied to the Solver.
 //
-// The maximum word length is the size of the board, and the minimum word
-// length is 3 letters.
-func New(xlen, ylen int, wordsPath string) (Solver, error) {
+// The maximum word length is the size of the board, unless set lower by
+// WithMaxLength, and the minimum word length is DefaultMinWordLength, unless
+// set by WithMinLength. If the words file has words, but none of them are left
+// after filtering, then an error wrapping ErrEmptyDictionary is returned that
+// gives the number of words removed for each reason. This usually means the
+// words file does not suit the board.
+func New(xlen, ylen int, wordsPath string, options ...Option) (Solver, error) {
+	return newSolver(xlen, ylen, nil, wordsPath, options)
+}
+
+// NewMasked creates a Solver for a board where some squares are absent. This
+// allows solving boards that are not rectangular, such as triangles, crosses,
+// or letter shapes, that fit within an xlen by ylen rectangle.
+//
+// The mask must have one value for each square of the board, and a false
+// value marks a square as absent. Absent squares are never part of any path
+// through the board, and may hold any character in a grid given to Solve. The
+// maximum word length is the number of squares present on the board. The
+// remaining arguments are the same as for New.
+func NewMasked(xlen, ylen int, mask []bool, wordsPath string, options ...Option) (Solver, error) {
+	if len(mask) != xlen*ylen {
+		return Solver{}, fmt.Errorf("%w: mask has %d squares, need %d", ErrInvalidDimensions, len(mask), xlen*ylen)
+	}
+	return newSolver(xlen, ylen, append([]bool(nil), mask...), wordsPath, options)
+}
+
+func newSolver(xlen, ylen int, mask []bool, wordsPath string, options []Option) (Solver, error) {
 	if xlen < 1 || ylen < 1 {
-		return Solver{}, errors.New("invalid board dimensions")
+		return Solver{}, fmt.Errorf("%w: %dx%d", ErrInvalidDimensions, xlen, ylen)
+	}
+	cfg := getOpts(options)
+	maxSize := cfg.maxBoardSize
+	if maxSize < 1 {
+		maxSize = DefaultMaxBoardSize
+	}
+	// Compare without multiplying, which could overflow.
+	if xlen > maxSize/ylen {
+		return Solver{}, fmt.Errorf("%w: %dx%d board has more than %d squares", ErrBoardTooLarge, xlen, ylen, maxSize)
 	}
 
-	rt, err := loadWords(wordsPath, xlen*ylen, 3)
+	maxLen := xlen * ylen
+	if mask != nil {
+		maxLen = 0
+		for _, present := range mask {
+			if present {
+				maxLen++
+			}
+		}
+	}
+
+	minLen, maxLen, err := wordLengths(cfg, maxLen)
+	if err != nil {
+		return Solver{}, err
+	}
+	var freq map[string]int
+	if cfg.freqColumn {
+		freq = make(map[string]int)
+	}
+	rt, err := loadWords(wordsPath, maxLen, minLen, cfg, freq)
 	if err != nil {
 		return Solver{}, err
 	}
+	var rev dictionary
+	if cfg.reverse {
+		rev = newDictionary(reverseTree(rt), cfg, true)
+	}
 
 	return Solver{
 		cols: xlen,
 		rows: ylen,
-		rt:   rt,
+		dict: newDictionary(rt, cfg, false),
+		rev:  rev,
+		freq: freq,
+		cfg:  cfg,
+		adj:  adjacencyTable(xlen, ylen, mask),
+		mask: mask,
+
+		minLen: minLen,
+		maxLen: maxLen,
+		mu:     new(sync.RWMutex),
 	}, nil
 }
 
@@ -81,90 +164,439 @@ func (s Solver) Dimensions() (int, int) {
 
 // WordCount returns the number of words read from the words file.
 func (s Solver) WordCount() int {
-	return s.rt.Len()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.dict.Len()
+}
+
+// MinWordLength returns the minimum number of letters in the words that the
+// Solver searches for. Shorter words in the words file are not loaded.
+func (s Solver) MinWordLength() int {
+	return s.minLen
+}
+
+// MaxWordLength returns the maximum number of letters in the words that the
+// Solver searches for. Longer words in the words file are not loaded.
+func (s Solver) MaxWordLength() int {
+	return s.maxLen
+}
+
+// AdjacencyGraph returns the squares adjacent to each square of the board, as
+// used when searching for words. This is useful for rendering or checking the
+// connectivity of the board, and does not depend on the dictionary. Squares
+// that are absent from a masked board are not included. The returned map is a
+// copy that may be modified by the caller.
+func (s Solver) AdjacencyGraph() map[int][]int {
+	graph := make(map[int][]int, len(s.adj))
+	for sq, sqAdj := range s.adj {
+		if s.mask != nil && !s.mask[sq] {
+			continue
+		}
+		graph[sq] = append([]int(nil), sqAdj...)
+	}
+	return graph
 }
 
 // Solve generates all solutions for the given Boggle grid.
 //
 // The grid argument is a string of X*Y characters, representing the letters in
 // a Boggle grid, from top left to bottom right. This method returns a slice of
-// the words that were found in the grid.
+// the words that were found in the grid. The words are sorted unless the
+// Solver was created using WithSortResults(false).
+//
+// An error wrapping ErrGridTooShort, ErrGridTooLong, or ErrInvalidCharacter is
+// returned if the grid is not valid for the Solver. A grid shorter than the
+// board is padded with blank squares if the Solver was created using
+// WithPadding(true). If the Solver was created using WithNodeLimit and the
+// limit is reached, then the words found so far are returned along with
+// ErrNodeLimit.
+//
+// The board is searched by multiple goroutines, as set by WithConcurrency,
+// unless the board is small or the Solver was created using WithNodeLimit.
 func (s Solver) Solve(grid string) ([]string, error) {
-	if s.rt == nil {
-		return nil, errors.New("failed to read words file")
+	workers := s.concurrency()
+	if workers < 2 || s.BoardSize() < minParallelSquares || s.cfg.nodeLimit > 0 {
+		return s.SolveWithScratch(grid, &Scratch{})
 	}
-	if len(grid) != s.BoardSize() {
-		if len(grid) < s.BoardSize() {
-			return nil, errors.New("not enough letters for board")
-		}
-		return nil, errors.New("too many letters for board")
+	board, err := s.board(grid)
+	if err != nil {
+		return nil, err
+	}
+	return s.solveParallel(board, workers), nil
+}
+
+// resultCapacity returns the number of words to allocate room for when
+// collecting the results of a solve.
+func (s Solver) resultCapacity() int {
+	if s.cfg.resultCap < 1 {
+		return DefaultResultCapacity
 	}
+	return s.cfg.resultCap
+}
 
-	board := strings.ToLower(grid)
-	words := make([]string, 0, 256)
-	q := deque.New[qNode](s.BoardSize(), s.BoardSize())
+// SolveProgress generates all solutions for the given Boggle grid, the same as
+// Solve, and calls progress after searching from each square of the board.
+// The progress function is given the number of squares searched from so far,
+// and the total number of squares, which allows showing the progress of
+// solving a large board. The search stops early, without a final call to
+// progress, if the search node limit set by WithNodeLimit is reached.
+func (s Solver) SolveProgress(grid string, progress func(done, total int)) ([]string, error) {
+	return s.solveScratch(grid, &Scratch{}, progress)
+}
+
+// FirstWord returns the first word found in the given Boggle grid, and true
+// if any word is found. The search stops as soon as a word is found, which
+// makes this a fast way to check that a board has any solution while also
+// getting an example word. Which word is returned depends on the order in
+// which the board is searched, and is not necessarily the first word in
+// alphabetical order.
+func (s Solver) FirstWord(grid string) (string, bool, error) {
+	board, err := s.board(grid)
+	if err != nil {
+		return "", false, err
+	}
+
+	var word string
+	var ok bool
+	sc := &Scratch{}
 	for initSq := 0; initSq < len(board); initSq++ {
-		seen := make([]int, 1, 8)
-		seen[0] = initSq
-		stepper := s.rt.NewStepper()
-		if !stepper.Next(board[initSq]) {
-			continue // no words starting with this letter
-		}
-		q.PushBack(qNode{
-			parentSquare: initSq,
-			parentTrie:   stepper,
-			seen:         seen,
+		done := !s.search(sc, board, initSq, func(w string, _ []int) bool {
+			word, ok = s.output(w)
+			return !ok
 		})
-		for q.Len() != 0 {
-			qn := q.PopFront()
-			parentSq := qn.parentSquare
-			parentTrie := qn.parentTrie
-			seen = qn.seen
-			sqAdj := calculateAdjacency(s.cols, s.rows, parentSq)
-		AdjLoop:
-			for _, curSq := range sqAdj {
-				for i := range seen {
-					if seen[i] == curSq {
-						continue AdjLoop
-					}
-				}
-				curNode := parentTrie.Copy()
-				if !curNode.Next(board[curSq]) {
-					continue
+		if done {
+			break
+		}
+	}
+	if ok {
+		return word, true, nil
+	}
+	return "", false, sc.nodeErr()
+}
+
+// SolveCount returns the number of distinct words found in the given Boggle
+// grid. This is cheaper than Solve when only the count is needed, since the
+// found words are not collected and sorted.
+func (s Solver) SolveCount(grid string) (int, error) {
+	return s.countWords(grid, func(string) bool { return true })
+}
+
+// SolveCountByLength returns the number of distinct words, of exactly the
+// given length, found in the given Boggle grid. The length of a word is the
+// number of letters in the word, so a word using the Qu tile counts both
+// letters of the tile.
+func (s Solver) SolveCountByLength(grid string, length int) (int, error) {
+	return s.countWords(grid, func(word string) bool {
+		return len(word) == length
+	})
+}
+
+// countWords returns the number of distinct words found in the grid for which
+// the match function returns true.
+func (s Solver) countWords(grid string, match func(word string) bool) (int, error) {
+	board, err := s.board(grid)
+	if err != nil {
+		return 0, err
+	}
+
+	counted := make(map[string]struct{})
+	sc := &Scratch{}
+	for initSq := 0; initSq < len(board); initSq++ {
+		if !s.search(sc, board, initSq, func(word string, _ []int) bool {
+			if !match(word) {
+				return true
+			}
+			if out, ok := s.output(word); ok {
+				counted[out] = struct{}{}
+			}
+			return true
+		}) {
+			break
+		}
+	}
+	return len(counted), sc.nodeErr()
+}
+
+// SolveTree generates all solutions for the given Boggle grid, and returns
+// them in a new radix tree. This allows prefix queries, such as for
+// autocomplete, over only the words on the board. The tree's keys are the
+// words as returned by Solve, and each value is the word as it appears in the
+// dictionary.
+//
+// The returned tree is independent of the Solver's dictionary, and can be
+// modified without affecting the Solver.
+func (s Solver) SolveTree(grid string) (*radixtree.Tree, error) {
+	board, err := s.board(grid)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := radixtree.New()
+	sc := &Scratch{}
+	for initSq := 0; initSq < len(board); initSq++ {
+		if !s.search(sc, board, initSq, func(word string, _ []int) bool {
+			if out, ok := s.output(word); ok {
+				tree.Put(out, word)
+			}
+			return true
+		}) {
+			break
+		}
+	}
+	return tree, sc.nodeErr()
+}
+
+// padSquare is the character of the blank squares that pad a short grid when
+// the Solver is created WithPadding.
+const padSquare = '.'
+
+// board checks that the grid is valid for the Solver and returns the board to
+// search.
+func (s Solver) board(grid string) (string, error) {
+	if s.dict == nil {
+		return "", ErrNoDictionary
+	}
+	filled := len(grid)
+	if s.cfg.pad && filled < s.BoardSize() {
+		grid += strings.Repeat(string(padSquare), s.BoardSize()-filled)
+	}
+	if err := checkGridSize(grid, s.BoardSize()); err != nil {
+		return "", err
+	}
+	board := grid
+	if !s.cfg.caseSensitive {
+		board = strings.ToLower(grid)
+	}
+	for sq := 0; sq < filled; sq++ {
+		if c := board[sq]; !isLetter(c) || (c < 'a' && !s.cfg.caseSensitive) {
+			if s.mask != nil && !s.mask[sq] {
+				continue // any character allowed in absent square
+			}
+			return "", fmt.Errorf("%w: %q at square %d", ErrInvalidCharacter, grid[sq], sq)
+		}
+	}
+	return board, nil
+}
+
+// foundFunc is called with each dictionary word completed on a path through
+// the board. The word is as it appears in the dictionary, and the path is the
+// squares visited to spell the word, and must not be retained. Returning false
+// stops the search.
+type foundFunc func(word string, path []int) bool
+
+// search looks for words along all paths through the board that begin at the
+// initSq square, calling found for each word completed. The buffers in the
+// given Scratch are used for the search. Returns false if found stopped the
+// search.
+func (s Solver) search(sc *Scratch, board string, initSq int, found foundFunc) bool {
+	if s.mask != nil && !s.mask[initSq] {
+		return true // square not present on board
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stepper := s.dict.stepper()
+	if !stepper.next(board[initSq]) {
+		return true // no words starting with this letter
+	}
+	if !s.explore(sc) {
+		return false
+	}
+	q := sc.queue(len(board))
+	sc.paths = append(sc.paths[:0], initSq)
+	if word, ok := stepper.word(); ok {
+		if !found(word, sc.paths[:1]) {
+			return false
+		}
+	}
+	q.PushBack(qNode{
+		parentSquare: initSq,
+		parentTrie:   stepper,
+		seenStart:    0,
+		seenLen:      1,
+	})
+	for q.Len() != 0 {
+		qn := q.PopFront()
+		parentSq := qn.parentSquare
+		parentTrie := qn.parentTrie
+		seenEnd := qn.seenStart + qn.seenLen
+		sqAdj := s.adj[parentSq]
+	AdjLoop:
+		for _, curSq := range sqAdj {
+			seen := sc.paths[qn.seenStart:seenEnd]
+			for i := range seen {
+				if seen[i] == curSq {
+					continue AdjLoop
 				}
-				newSeen := make([]int, len(seen)+1)
-				copy(newSeen, seen)
-				newSeen[len(seen)] = curSq
-
-				q.PushBack(qNode{
-					parentSquare: curSq,
-					parentTrie:   curNode,
-					seen:         newSeen,
-				})
-				if item := curNode.Item(); item != nil {
-					key := item.Key()
-					if key[0] == 'q' {
-						// Rehydrate q-words with 'u'.
-						words = append(words, "qu"+key[1:])
-					} else {
-						words = append(words, key)
-					}
+			}
+			curNode := parentTrie.copy()
+			if !curNode.next(board[curSq]) {
+				continue
+			}
+			if !s.explore(sc) {
+				q.Clear()
+				return false
+			}
+			newStart := len(sc.paths)
+			sc.paths = append(sc.paths, seen...)
+			sc.paths = append(sc.paths, curSq)
+			newSeen := sc.paths[newStart:]
+
+			q.PushBack(qNode{
+				parentSquare: curSq,
+				parentTrie:   curNode,
+				seenStart:    newStart,
+				seenLen:      len(newSeen),
+			})
+			if word, ok := curNode.word(); ok {
+				if !found(word, newSeen) {
+					q.Clear()
+					return false
 				}
 			}
 		}
 	}
+	return true
+}
+
+// explore counts a search node explored, and returns false if the search
+// node limit has been reached or the search deadline has passed.
+func (s Solver) explore(sc *Scratch) bool {
+	if s.cfg.nodeLimit > 0 && sc.nodes >= s.cfg.nodeLimit {
+		sc.limited = true
+		return false
+	}
+	sc.nodes++
+	if !sc.deadline.IsZero() && sc.nodes%deadlineCheckInterval == 0 && time.Now().After(sc.deadline) {
+		sc.expired = true
+		return false
+	}
+	return true
+}
 
-	return uniqueSortedWords(words), nil
+// word returns the dictionary word in the Solver's word case.
+func (s Solver) word(word string) string {
+	switch s.cfg.wordCase {
+	case UpperCase:
+		return strings.ToUpper(word)
+	case TitleCase:
+		return strings.ToUpper(word[:1]) + word[1:]
+	}
+	return word
+}
+
+// output returns a dictionary word as it is returned in the results of a
+// solve: in the Solver's word case, and replaced by the word filter if one is
+// set. Returns false if the word filter drops the word.
+func (s Solver) output(word string) (string, bool) {
+	word = s.word(word)
+	if s.cfg.wordFilter == nil {
+		return word, true
+	}
+	return s.cfg.wordFilter(word)
+}
+
+// checkGridSize returns an error if the grid does not have size letters.
+func checkGridSize(grid string, size int) error {
+	if len(grid) < size {
+		return fmt.Errorf("%w: have %d, need %d", ErrGridTooShort, len(grid), size)
+	}
+	if len(grid) > size {
+		return fmt.Errorf("%w: have %d, need %d", ErrGridTooLong, len(grid), size)
+	}
+	return nil
 }
 
 // Grid returns a printable string version of a X by Y boggle grid.
 //
 // The grid is given as a string of X*Y characters representing the letters in
-// a boggle grid, from top left to bottom right.
+// a boggle grid, from top left to bottom right. Squares that are absent from a
+// masked board are shown empty.
+//
+// The rows are printed top to bottom according to the origin set by
+// WithOrigin.
 func (s Solver) Grid(grid string) string {
+	grid = s.blankMasked(grid)
+	if s.cfg.origin == BottomLeft && len(grid) == s.cols*s.rows {
+		grid = flipRows(grid, s.cols)
+	}
 	return GridString(grid, s.cols, s.rows)
 }
 
+// GridIndexed returns a printable string version of a X by Y boggle grid, the
+// same as Grid, with each square labeled by its index in the grid.
+func (s Solver) GridIndexed(grid string) string {
+	return gridStringIndexed(s.blankMasked(grid), s.cols, s.rows, s.cfg.origin)
+}
+
+// flipRows returns the grid with the order of its rows reversed.
+func flipRows(grid string, cols int) string {
+	flipped := make([]byte, 0, len(grid))
+	for end := len(grid); end > 0; end -= cols {
+		flipped = append(flipped, grid[end-cols:end]...)
+	}
+	return string(flipped)
+}
+
+// CanonicalGrid returns the canonical form of a X by Y boggle grid, which is
+// the same for every grid that is a rotation or reflection of it. Since such
+// grids have the same solutions, this detects equivalent boards, such as when
+// removing duplicates from a collection of puzzles.
+//
+// The canonical form is the first, in alphabetical order, of the grid's
+// symmetries, in lower case. A square board has 8 symmetries: 4 rotations, and
+// the reflection of each. A board that is not square has 4 symmetries that
+// keep its dimensions: the grid itself, its reflections across the middle row
+// and the middle column, and its rotation by 180 degrees.
+func CanonicalGrid(grid string, cols, rows int) string {
+	if len(grid) != cols*rows {
+		panic("number of letters in grid must equal cols * rows")
+	}
+	grid = strings.ToLower(grid)
+	// Each symmetry gives the source square of the square at row r, column c.
+	symmetries := []func(r, c int) int{
+		func(r, c int) int { return r*cols + (cols - 1 - c) },
+		func(r, c int) int { return (rows-1-r)*cols + c },
+		func(r, c int) int { return (rows-1-r)*cols + (cols - 1 - c) },
+	}
+	if cols == rows {
+		n := cols
+		symmetries = append(symmetries,
+			func(r, c int) int { return c*n + r },
+			func(r, c int) int { return (n-1-c)*n + r },
+			func(r, c int) int { return c*n + (n - 1 - r) },
+			func(r, c int) int { return (n-1-c)*n + (n - 1 - r) },
+		)
+	}
+	canonical := grid
+	cells := make([]byte, len(grid))
+	for _, src := range symmetries {
+		for r := 0; r < rows; r++ {
+			for c := 0; c < cols; c++ {
+				cells[r*cols+c] = grid[src(r, c)]
+			}
+		}
+		if g := string(cells); g < canonical {
+			canonical = g
+		}
+	}
+	return canonical
+}
+
+// blankMasked returns the grid with squares absent from a masked board
+// replaced by spaces.
+func (s Solver) blankMasked(grid string) string {
+	if s.mask == nil || len(grid) != len(s.mask) {
+		return grid
+	}
+	g := []byte(grid)
+	for i, present := range s.mask {
+		if !present {
+			g[i] = ' '
+		}
+	}
+	return string(g)
+}
+
 func GridString(grid string, cols, rows int) string {
 	if len(grid) != cols*rows {
 		panic("number of letters in grid must equal cols * rows")
@@ -199,15 +631,319 @@ func GridString(grid string, cols, rows int) string {
 	return strings.Join(append(gridLines, ""), hline)
 }
 
+// GridStringIndexed returns a printable string version of a X by Y boggle
+// grid, with each square labeled by its index in the grid followed by its
+// letter. This helps when debugging paths, which are sequences of square
+// indexes. The labels are padded so that columns stay aligned for any number
+// of squares.
+func GridStringIndexed(grid string, cols, rows int) string {
+	return gridStringIndexed(grid, cols, rows, TopLeft)
+}
+
+// gridStringIndexed returns a printable string version of a grid with each
+// square labeled by its index, with the rows printed according to origin.
+func gridStringIndexed(grid string, cols, rows int, origin Origin) string {
+	if len(grid) != cols*rows {
+		panic("number of letters in grid must equal cols * rows")
+	}
+	grid = strings.ToUpper(grid)
+	width := len(strconv.Itoa(len(grid) - 1))
+
+	line := make([]string, 0, cols+2)
+	line = append(line, "")
+	for i := 0; i < cols; i++ {
+		line = append(line, strings.Repeat("-", width+5))
+	}
+	line = append(line, "\n")
+	hline := strings.Join(line, "+")
+
+	gridLines := make([]string, 0, 2*rows+1)
+	gridLines = append(gridLines, "")
+	for y := 0; y < rows; y++ {
+		row := y
+		if origin == BottomLeft {
+			row = rows - 1 - y
+		}
+		for x := 0; x < cols; x++ {
+			sq := row*cols + x
+			letter := string(grid[sq])
+			if letter == "Q" {
+				letter = "Qu"
+			}
+			line[1+x] = fmt.Sprintf(" %*d %-2s ", width, sq, letter)
+		}
+		gridLines = append(gridLines, strings.Join(line, "|"))
+	}
+	return strings.Join(append(gridLines, ""), hline)
+}
+
+// GridStringWithPath returns a printable string version of a X by Y boggle
+// grid, with each square of the path labeled by its step number along the
+// path, starting from 1. This shows where a word is traced on the board.
+// Squares that are not on the path are not labeled.
+func GridStringWithPath(grid string, cols, rows int, path []int) string {
+	return gridStringWithPath(grid, cols, rows, TopLeft, path)
+}
+
+// gridStringWithPath returns a printable string version of a grid with each
+// square of the path labeled by its step number, with the rows printed
+// according to origin.
+func gridStringWithPath(grid string, cols, rows int, origin Origin, path []int) string {
+	if len(grid) != cols*rows {
+		panic("number of letters in grid must equal cols * rows")
+	}
+	steps := make([]int, len(grid))
+	for i, sq := range path {
+		if sq < 0 || sq >= len(grid) {
+			panic("path square not in grid")
+		}
+		steps[sq] = i + 1
+	}
+	grid = strings.ToUpper(grid)
+	width := len(strconv.Itoa(len(path)))
+
+	line := make([]string, 0, cols+2)
+	line = append(line, "")
+	for i := 0; i < cols; i++ {
+		line = append(line, strings.Repeat("-", width+5))
+	}
+	line = append(line, "\n")
+	hline := strings.Join(line, "+")
+
+	gridLines := make([]string, 0, 2*rows+1)
+	gridLines = append(gridLines, "")
+	for y := 0; y < rows; y++ {
+		row := y
+		if origin == BottomLeft {
+			row = rows - 1 - y
+		}
+		for x := 0; x < cols; x++ {
+			sq := row*cols + x
+			letter := string(grid[sq])
+			if letter == "Q" {
+				letter = "Qu"
+			}
+			step := strings.Repeat(" ", width)
+			if steps[sq] != 0 {
+				step = strconv.Itoa(steps[sq])
+			}
+			line[1+x] = fmt.Sprintf(" %*s %-2s ", width, step, letter)
+		}
+		gridLines = append(gridLines, strings.Join(line, "|"))
+	}
+	return strings.Join(append(gridLines, ""), hline)
+}
+
 // loadWords reads a file of words and creates a trie containing them. If no
 // file name is specified then the embedded words list is loaded.
-func loadWords(filePath string, maxLen, minLen int) (*radixtree.Tree, error) {
+//
+// Each word is stored in the trie using a key that has any leading "qu"
+// replaced by "q", so that the Qu tile matches. The value stored with each key
+// is the original word, which is what is returned in solutions.
+//
+// If freq is not nil, then each line of the file has a word and its frequency,
+// and the frequency of each word put into the trie is stored in freq.
+func loadWords(filePath string, maxLen, minLen int, cfg config, freq map[string]int) (*radixtree.Tree, error) {
+	tree := radixtree.New()
+	var scanned, loaded int
+	rejected := make(map[string]int)
+	// addWord puts the word into the tree, and returns false if the word is
+	// rejected.
+	addWord := func(word string) bool {
+		if word == "" {
+			return false // blank line
+		}
+		scanned++
+		key, reason := filterWord(word, maxLen, minLen, cfg.caseSensitive)
+		if cfg.audit != nil {
+			cfg.audit(word, reason == "", reason)
+		}
+		if reason != "" {
+			rejected[reason]++
+			return false
+		}
+		if tree.Put(key, word) {
+			loaded++
+			if cfg.progress != nil && loaded%LoadProgressInterval == 0 {
+				cfg.progress(loaded)
+			}
+		}
+		return true
+	}
+	err := scanEntries(filePath, cfg, func(word string, n int) {
+		if addWord(word) && freq != nil {
+			freq[word] = n
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cfg.progress != nil && loaded%LoadProgressInterval != 0 {
+		cfg.progress(loaded)
+	}
+
+	if cfg.denyPath != "" {
+		err = scanWords(cfg.denyPath, func(word string) {
+			key := normalizeWord(word, cfg.caseSensitive)
+			stored, ok := tree.Get(key)
+			if !ok {
+				return
+			}
+			tree.Delete(key)
+			rejected[ReasonDenied]++
+			if cfg.audit != nil {
+				cfg.audit(stored.(string), false, ReasonDenied)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if tree.Len() == 0 && scanned != 0 {
+		return nil, emptyDictionaryError(scanned, rejected)
+	}
+	return tree, nil
+}
+
+// scanEntries reads a words file, calling entryFn with each word and its
+// frequency. Lines are split into words as set by WithWordSeparator, or into a
+// word and its frequency as set by WithFrequencyColumn. The frequency is zero
+// if the file has no frequencies.
+func scanEntries(filePath string, cfg config, entryFn func(word string, freq int)) error {
+	return scanWords(filePath, func(line string) {
+		switch {
+		case cfg.freqColumn:
+			entryFn(parseFrequency(line))
+		case cfg.sep == "" || cfg.sep == "\n":
+			entryFn(line, 0)
+		default:
+			for _, word := range strings.Split(line, cfg.sep) {
+				entryFn(word, 0)
+			}
+		}
+	})
+}
+
+// DictionaryReport describes the words read from a words file by
+// CheckDictionary.
+type DictionaryReport struct {
+	// Scanned is the number of words read from the file, not counting blank
+	// lines.
+	Scanned int
+	// Accepted is the number of words that would be put into the dictionary.
+	// A word that appears more than once in the file is counted each time.
+	Accepted int
+	// Rejected maps each reason for rejecting words, such as ReasonTooShort,
+	// to the number of words rejected for that reason.
+	Rejected map[string]int
+}
+
+// CheckDictionary reads a words file and filters its words the same as New
+// does for a board of the given dimensions, without building a dictionary.
+// This checks that a words file is readable, correctly compressed, and has
+// usable words, in less time than creating a Solver. The options that affect
+// reading words, such as WithWordSeparator, WithMinLength, WithMaxLength, and
+// WithAudit, are applied. A deny file set by WithDenyFile is not read.
+//
+// The returned report counts the accepted and rejected words read before any
+// error. An error wrapping ErrEmptyDictionary is returned if the file has
+// words but none are accepted. As with New, an error wrapping
+// ErrInvalidWordLength is returned if the word lengths set by WithMinLength
+// and WithMaxLength are not possible on the board.
+func CheckDictionary(wordsPath string, xlen, ylen int, options ...Option) (DictionaryReport, error) {
+	if xlen < 1 || ylen < 1 {
+		return DictionaryReport{}, fmt.Errorf("%w: %dx%d", ErrInvalidDimensions, xlen, ylen)
+	}
+	cfg := getOpts(options)
+	minLen, maxLen, err := wordLengths(cfg, xlen*ylen)
+	if err != nil {
+		return DictionaryReport{}, err
+	}
+	report := DictionaryReport{
+		Rejected: make(map[string]int),
+	}
+	err = scanEntries(wordsPath, cfg, func(word string, _ int) {
+		if word == "" {
+			return // blank line
+		}
+		report.Scanned++
+		_, reason := filterWord(word, maxLen, minLen, cfg.caseSensitive)
+		if cfg.audit != nil {
+			cfg.audit(word, reason == "", reason)
+		}
+		if reason != "" {
+			report.Rejected[reason]++
+			return
+		}
+		report.Accepted++
+	})
+	if err != nil {
+		return report, err
+	}
+	if report.Accepted == 0 && report.Scanned != 0 {
+		return report, emptyDictionaryError(report.Scanned, report.Rejected)
+	}
+	return report, nil
+}
+
+// wordLengths returns the minimum and maximum lengths of dictionary words for a
+// board with boardLen usable squares, as set by WithMinLength and
+// WithMaxLength. An error wrapping ErrInvalidWordLength is returned if the
+// configured lengths are not possible on the board.
+func wordLengths(cfg config, boardLen int) (int, int, error) {
+	minLen, maxLen := DefaultMinWordLength, boardLen
+	if cfg.minLenSet {
+		minLen = cfg.minLen
+		if minLen < 1 || minLen > maxLen {
+			return 0, 0, fmt.Errorf("%w: minimum %d must be 1 to %d", ErrInvalidWordLength, minLen, maxLen)
+		}
+	}
+	if cfg.maxLenSet {
+		if cfg.maxLen < minLen {
+			return 0, 0, fmt.Errorf("%w: maximum %d is less than minimum %d", ErrInvalidWordLength, cfg.maxLen, minLen)
+		}
+		maxLen = min(maxLen, cfg.maxLen)
+	}
+	return minLen, maxLen, nil
+}
+
+// parseFrequency parses a line of a words file that has a word and its
+// frequency, separated by white space. The frequency is zero if it is missing
+// or not a number.
+func parseFrequency(line string) (string, int) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", 0
+	}
+	var n int
+	if len(fields) > 1 {
+		n, _ = strconv.Atoi(fields[1])
+	}
+	return fields[0], n
+}
+
+// emptyDictionaryError returns an error wrapping ErrEmptyDictionary that
+// explains why each of the scanned words was not used.
+func emptyDictionaryError(scanned int, rejected map[string]int) error {
+	reasons := make([]string, 0, len(rejected))
+	for reason, n := range rejected {
+		reasons = append(reasons, fmt.Sprintf("%s: %d", reason, n))
+	}
+	slices.Sort(reasons)
+	return fmt.Errorf("%w: all %d words removed (%s)", ErrEmptyDictionary, scanned,
+		strings.Join(reasons, ", "))
+}
+
+// scanWords reads a file of line-delimited words, calling wordFn with each
+// word. If no file name is specified then the embedded words list is read.
+func scanWords(filePath string, wordFn func(word string)) error {
 	var rdr io.Reader
 	var gz bool
 	if filePath == "" {
 		f, err := wordsFile.Open(defaultWords)
 		if err != nil {
-			return nil, fmt.Errorf("solver: error opening words file: %s", err)
+			return fmt.Errorf("solver: error opening words file: %w", err)
 		}
 		defer f.Close()
 		rdr = f
@@ -215,7 +951,7 @@ func loadWords(filePath string, maxLen, minLen int) (*radixtree.Tree, error) {
 	} else {
 		f, err := os.Open(filePath)
 		if err != nil {
-			return nil, fmt.Errorf("solver: error opening words file: %s", err)
+			return fmt.Errorf("solver: error opening words file: %w", err)
 		}
 		defer f.Close()
 		rdr = f
@@ -225,49 +961,90 @@ func loadWords(filePath string, maxLen, minLen int) (*radixtree.Tree, error) {
 		var err error
 		rdr, err = gzip.NewReader(rdr)
 		if err != nil {
-			return nil, fmt.Errorf("solver: error unzipping words file: %s", err)
+			return fmt.Errorf("solver: error unzipping words file: %w", err)
 		}
 	}
 
 	scanner := bufio.NewScanner(rdr)
-	tree := radixtree.New()
 
 	// Scan through line-dilimited words.
 	for scanner.Scan() {
-		word := scanner.Text()
-		// Skip words that are too long or too short.
-		if len(word) > maxLen || len(word) < minLen {
-			continue
-		}
-		// Skip words that start with a capital letter.
-		if int(word[0]) < 'a' {
-			continue
-		}
-		// If word starts wit qu then remove u so that only q is mathced.
-		if int(word[0]) == 'q' {
-			// Skip words that start with q not followed by u.
-			if int(word[1]) != 'u' {
-				continue
-			}
-			word = "q" + word[2:]
+		wordFn(scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("solver: error reading words file: %w", err)
+	}
+	return nil
+}
+
+// filterWord returns the key to store for the given word, or the reason the
+// word is rejected.
+// If caseSensitive is true, then words that start with a capital letter are
+// kept.
+func filterWord(word string, maxLen, minLen int, caseSensitive bool) (string, string) {
+	// Skip words that are too long or too short.
+	if len(word) > maxLen {
+		return "", ReasonTooLong
+	}
+	if len(word) < minLen {
+		return "", ReasonTooShort
+	}
+	// Skip words that start with a capital letter.
+	if int(word[0]) < 'a' && !caseSensitive {
+		return "", ReasonCapitalized
+	}
+	// If word starts wit qu then remove u so that only q is mathced.
+	if int(word[0]) == 'q' || (caseSensitive && word[0] == 'Q') {
+		// Skip words that start with q not followed by u.
+		if len(word) < 2 || word[1] != 'u' {
+			return "", ReasonQWithoutU
 		}
+		word = word[:1] + word[2:]
+	}
+	return word, ""
+}
 
-		tree.Put(word, nil)
+// NormalizeWord returns the form in which a word is stored in a Solver's
+// dictionary. The word is lower cased and a leading "qu" is replaced by "q",
+// since the Qu tile is given as 'q' in a grid. Normalize words before
+// comparing them with the dictionary's stored forms, such as when validating
+// words entered by players, so that they match the same way as when solving.
+func NormalizeWord(word string) string {
+	word = strings.ToLower(word)
+	if strings.HasPrefix(word, "qu") {
+		word = "q" + word[2:]
 	}
+	return word
+}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("solver: error reading words file: %s", err)
+// normalizeWord returns the form in which a word is stored in a dictionary,
+// the same as NormalizeWord if caseSensitive is false. Otherwise, the case of
+// the word is kept, and a leading "qu" or "Qu" is replaced by "q" or "Q".
+func normalizeWord(word string, caseSensitive bool) string {
+	if !caseSensitive {
+		return NormalizeWord(word)
 	}
+	if len(word) > 1 && (word[0] == 'q' || word[0] == 'Q') && word[1] == 'u' {
+		word = word[:1] + word[2:]
+	}
+	return word
+}
 
-	return tree, nil
+// isLetter returns true if c is an ASCII letter of either case.
+func isLetter(c byte) bool {
+	c |= 0x20 // lower case
+	return c >= 'a' && c <= 'z'
 }
 
+// uniqueSortedWords sorts the words and removes duplicates, reusing the given
+// slice.
 func uniqueSortedWords(words []string) []string {
 	if len(words) == 0 {
 		return words
 	}
-	sort.Sort(sort.StringSlice(words))
-	unique := make([]string, 0, len(words))
+	slices.Sort(words)
+	unique := words[:0]
 	var prev string
 	for _, w := range words {
 		if w != prev {
@@ -278,18 +1055,57 @@ func uniqueSortedWords(words []string) []string {
 	return unique
 }
 
+// uniqueWords removes duplicate words, keeping the first occurrence of each.
+// The seen map is used to find duplicates, and is allocated if nil.
+func uniqueWords(words []string, seen map[string]struct{}) []string {
+	if len(words) == 0 {
+		return words
+	}
+	if seen == nil {
+		seen = make(map[string]struct{}, len(words))
+	}
+	unique := words[:0]
+	for _, w := range words {
+		if _, ok := seen[w]; !ok {
+			seen[w] = struct{}{}
+			unique = append(unique, w)
+		}
+	}
+	return unique
+}
+
+// adjacencyTable calculates the squares adjacent to every square of the
+// board. If mask is not nil, then squares that are not present are not
+// adjacent to any square.
+func adjacencyTable(xlim, ylim int, mask []bool) [][]int {
+	table := make([][]int, xlim*ylim)
+	for sq := range table {
+		if mask != nil && !mask[sq] {
+			continue
+		}
+		sqAdj := calculateAdjacency(xlim, ylim, sq)
+		table[sq] = make([]int, 0, len(sqAdj))
+		for _, a := range sqAdj {
+			if mask == nil || mask[a] {
+				table[sq] = append(table[sq], a)
+			}
+		}
+	}
+	return table
+}
+
 // calculateAdjacency calculates squares adjacent to the one given.
 //
 // Adjacent squares, up to eight, are calculated for the square specified by
-// the x and y coordinates and are written to the given slice.
+// the x and y coordinates and are returned in a new slice, so that adjacency
+// may be calculated by multiple goroutines at once.
 func calculateAdjacency(xlim, ylim, sq int) []int {
 	// Current cell index = y * xlim + x
 	y := sq / xlim
 	x := sq - (y * xlim)
 	var above, below int
 
-	// Clear the adj slice.
-	adj = adj[:0]
+	adj := make([]int, 0, 8)
 
 	// Look at row above current cell.
 	if y-1 >= 0 {
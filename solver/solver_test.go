@@ -1,20 +1,29 @@
 package solver
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 )
 
 const testWordsFile = "boggle_words.txt.gz"
 
 func TestLoadWords(t *testing.T) {
-	rt, err := loadWords("_not_here_", 16, 3)
-	if err == nil {
+	rt, err := loadWords("_not_here_", 16, 3, config{}, nil)
+	if !errors.Is(err, os.ErrNotExist) {
 		t.Fatal("failed to catch bad file")
 	}
 
 	// Load from embedded file.
-	rt, err = loadWords("", 16, 3)
+	rt, err = loadWords("", 16, 3, config{}, nil)
 	if rt == nil {
 		t.Fatal("expected trie")
 	}
@@ -24,7 +33,7 @@ func TestLoadWords(t *testing.T) {
 	fmt.Println("Loaded", rt.Len(), "words from embedded dictionary")
 
 	// Load from external file.
-	rt, err = loadWords("", 16, 3)
+	rt, err = loadWords("", 16, 3, config{}, nil)
 	if rt == nil {
 		t.Fatal("expected trie")
 	}
@@ -34,10 +43,240 @@ func TestLoadWords(t *testing.T) {
 	fmt.Println("Loaded", rt.Len(), "words from", testWordsFile)
 }
 
+func TestLoadWordsAudit(t *testing.T) {
+	wordsPath := writeWords(t, "cat", "at", "Paris", "quit", "qat", "abcdefghijklmnopq")
+
+	type decision struct {
+		word     string
+		accepted bool
+		reason   string
+	}
+	var decisions []decision
+	audit := func(word string, accepted bool, reason string) {
+		decisions = append(decisions, decision{word, accepted, reason})
+	}
+
+	rt, err := loadWords(wordsPath, 16, 3, config{audit: audit}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rt.Len() != 2 {
+		t.Fatal("expected 2 words, got", rt.Len())
+	}
+
+	expect := []decision{
+		{"cat", true, ""},
+		{"at", false, ReasonTooShort},
+		{"Paris", false, ReasonCapitalized},
+		{"quit", true, ""},
+		{"qat", false, ReasonQWithoutU},
+		{"abcdefghijklmnopq", false, ReasonTooLong},
+	}
+	if len(decisions) != len(expect) {
+		t.Fatalf("expected %d audit calls, got %d", len(expect), len(decisions))
+	}
+	for i := range expect {
+		if decisions[i] != expect[i] {
+			t.Errorf("expected %v, got %v", expect[i], decisions[i])
+		}
+	}
+}
+
+func TestOriginalWords(t *testing.T) {
+	wordsPath := writeWords(t, "quit", "qit", "tiq", "quiet")
+	rt, err := loadWords(wordsPath, 16, 3, config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	val, ok := rt.Get("qit")
+	if !ok || val != "quit" {
+		t.Fatal("expected original word stored for key")
+	}
+
+	s, err := New(2, 2, wordsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	words, err := s.Solve("qitx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"quit", "tiq"}) {
+		t.Fatal("wrong solutions:", words)
+	}
+}
+
+func TestNormalizeWord(t *testing.T) {
+	wordsPath := writeWords(t, "queen", "cat")
+	rt, err := loadWords(wordsPath, 16, 3, config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for word, expect := range map[string]string{
+		"Queen": "qeen",
+		"QUEEN": "qeen",
+		"cat":   "cat",
+		"Cat":   "cat",
+	} {
+		key := NormalizeWord(word)
+		if key != expect {
+			t.Errorf("expected %q for %q, got %q", expect, word, key)
+		}
+		if _, ok := rt.Get(key); !ok {
+			t.Errorf("normalized %q not found in dictionary", word)
+		}
+	}
+	if NormalizeWord("equal") != "equal" {
+		t.Error("only leading qu should be replaced")
+	}
+}
+
+func TestDenyFile(t *testing.T) {
+	wordsPath := writeWords(t, "cat", "eat", "queen", "quit", "tea")
+	denyPath := writeWords(t, "EAT", "Queen", "dog")
+
+	s, err := New(4, 4, wordsPath, WithDenyFile(denyPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.WordCount() != 3 {
+		t.Fatal("expected 3 words, got", s.WordCount())
+	}
+	words, err := s.Solve("catxqiexxxaxxxxx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"cat", "quit", "tea"}) {
+		t.Fatal("wrong solutions:", words)
+	}
+
+	// Words removed by the deny file are audited after being accepted.
+	var denied []string
+	_, err = New(4, 4, wordsPath, WithDenyFile(denyPath), WithAudit(func(word string, accepted bool, reason string) {
+		if !accepted && reason == ReasonDenied {
+			denied = append(denied, word)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(denied, []string{"eat", "queen"}) {
+		t.Fatal("wrong denied words:", denied)
+	}
+
+	_, err = New(4, 4, wordsPath, WithDenyFile("_not_here_"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("failed to catch bad deny file")
+	}
+}
+
+func TestEmptyDictionary(t *testing.T) {
+	wordsPath := writeWords(t, "at", "be", "of", "to")
+	_, err := New(4, 4, wordsPath)
+	if !errors.Is(err, ErrEmptyDictionary) {
+		t.Fatal("expected ErrEmptyDictionary, got", err)
+	}
+	if !strings.Contains(err.Error(), "all 4 words removed (too short: 4)") {
+		t.Fatal("unexpected error message:", err)
+	}
+
+	wordsPath = writeWords(t, "cat", "Dog", "tea")
+	denyPath := writeWords(t, "cat", "tea")
+	_, err = New(4, 4, wordsPath, WithDenyFile(denyPath))
+	if !errors.Is(err, ErrEmptyDictionary) {
+		t.Fatal("expected ErrEmptyDictionary, got", err)
+	}
+	if !strings.Contains(err.Error(), "(capitalized: 1, denied: 2)") {
+		t.Fatal("unexpected error message:", err)
+	}
+
+	// A words file with no words is not reported.
+	if _, err = New(4, 4, writeWords(t)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWordSeparator(t *testing.T) {
+	wordsFile := writeWords(t, "cat dog  tea", "act Cab ab", "", "god")
+
+	s, err := New(3, 3, wordsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.WordCount() != 1 {
+		t.Fatal("expected only one word without separator, got", s.WordCount())
+	}
+
+	var accepted, rejected []string
+	s, err = New(3, 3, wordsFile, WithWordSeparator(" "), WithAudit(func(word string, ok bool, _ string) {
+		if ok {
+			accepted = append(accepted, word)
+		} else {
+			rejected = append(rejected, word)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(accepted, []string{"cat", "dog", "tea", "act", "god"}) {
+		t.Fatal("wrong accepted words:", accepted)
+	}
+	if !reflect.DeepEqual(rejected, []string{"Cab", "ab"}) {
+		t.Fatal("wrong rejected words:", rejected)
+	}
+	// +---+---+---+
+	// | C | A | T |
+	// +---+---+---+
+	// | D | O | E |
+	// +---+---+---+
+	// | X | G | A |
+	// +---+---+---+
+	words, err := s.Solve("catdoexga")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"cat", "dog", "god", "tea"}) {
+		t.Fatal("wrong solutions:", words)
+	}
+}
+
+func TestLoadProgress(t *testing.T) {
+	var reports []int
+	s, err := New(4, 4, "", WithLoadProgress(func(wordsLoaded int) {
+		reports = append(reports, wordsLoaded)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := s.WordCount()
+	if len(reports) != (count+LoadProgressInterval-1)/LoadProgressInterval {
+		t.Fatal("wrong number of progress reports:", len(reports))
+	}
+	for i, n := range reports[:len(reports)-1] {
+		if n != (i+1)*LoadProgressInterval {
+			t.Fatal("wrong progress report:", n)
+		}
+	}
+	if reports[len(reports)-1] != count {
+		t.Fatal("last report was not total words loaded")
+	}
+
+	reports = nil
+	_, err = New(4, 4, writeWords(t, "cat", "ab", "tea"), WithLoadProgress(func(wordsLoaded int) {
+		reports = append(reports, wordsLoaded)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(reports, []int{2}) {
+		t.Fatal("wrong progress reports:", reports)
+	}
+}
+
 func TestCalcAdjacency(t *testing.T) {
 	// Test corners
 	sq := 0
-	adj = calculateAdjacency(4, 4, sq)
+	adj := calculateAdjacency(4, 4, sq)
 	//fmt.Println("adj:", adj)
 	if len(adj) != 3 || adj[0] != 1 || adj[1] != 4 || adj[2] != 5 {
 		t.Error("wrong adjacency for square", sq)
@@ -82,132 +321,1327 @@ func TestCalcAdjacency(t *testing.T) {
 
 }
 
-func TestUniqueSortedWords(t *testing.T) {
-	words := []string{"gamma", "delta", "alpha", "beta", "zeta", "delta", "delta"}
-	usw := uniqueSortedWords(words)
-	if len(usw) != 5 {
-		t.Fatal("wrong number of unique words")
-	}
+// adjacentFunc reports whether the square at (bx, by) is adjacent to the
+// square at (ax, ay).
+type adjacentFunc func(ax, ay, bx, by int) bool
 
-	for i, w := range []string{"alpha", "beta", "delta", "gamma", "zeta"} {
-		if w != usw[i] {
-			t.Fatal("words not sorted")
+// kingAdjacent is the standard boggle adjacency, where squares are adjacent
+// if they touch horizontally, vertically, or diagonally.
+func kingAdjacent(ax, ay, bx, by int) bool {
+	dx, dy := bx-ax, by-ay
+	return (dx != 0 || dy != 0) && dx >= -1 && dx <= 1 && dy >= -1 && dy <= 1
+}
+
+// bruteAdjacency is a reference implementation that finds the squares
+// adjacent to every square by checking every pair of squares.
+func bruteAdjacency(cols, rows int, mask []bool, adjacent adjacentFunc) [][]int {
+	table := make([][]int, cols*rows)
+	for a := range table {
+		if mask != nil && !mask[a] {
+			continue
+		}
+		table[a] = []int{}
+		for b := 0; b < cols*rows; b++ {
+			if mask != nil && !mask[b] {
+				continue
+			}
+			if adjacent(a%cols, a/cols, b%cols, b/cols) {
+				table[a] = append(table[a], b)
+			}
 		}
 	}
+	return table
 }
 
-func TestSolverBadNew(t *testing.T) {
-	_, err := New(4, 5, "_not_here_")
-	if err == nil {
-		t.Fatal("failed to catch bad file")
+// checkAdjacency compares an adjacency table to the brute force reference.
+func checkAdjacency(t *testing.T, cols, rows int, mask []bool, table [][]int, adjacent adjacentFunc) {
+	t.Helper()
+	expect := bruteAdjacency(cols, rows, mask, adjacent)
+	for sq := range expect {
+		if len(expect[sq]) == 0 && len(table[sq]) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(table[sq], expect[sq]) {
+			t.Fatalf("%dx%d board: wrong adjacency for square %d: expected %v, got %v",
+				cols, rows, sq, expect[sq], table[sq])
+		}
 	}
+}
 
-	_, err = New(-4, 5, "")
-	if err == nil {
-		t.Fatal("failed to catch negative dimension")
+func TestAdjacencyReference(t *testing.T) {
+	sizes := [][2]int{
+		{1, 1}, {1, 2}, {2, 1}, {1, 7}, {7, 1}, {2, 2}, {3, 3}, {4, 4},
+		{3, 5}, {5, 3}, {4, 5}, {13, 17}, {17, 13}, {50, 50}, {100, 3},
 	}
+	rng := rand.New(rand.NewSource(1))
+	for _, size := range sizes {
+		cols, rows := size[0], size[1]
 
-	_, err = New(4, 0, "")
-	if err == nil {
-		t.Fatal("failed to catch zero dimension")
+		table := make([][]int, cols*rows)
+		for sq := range table {
+			table[sq] = append([]int(nil), calculateAdjacency(cols, rows, sq)...)
+		}
+		checkAdjacency(t, cols, rows, nil, table, kingAdjacent)
+		checkAdjacency(t, cols, rows, nil, adjacencyTable(cols, rows, nil), kingAdjacent)
+
+		mask := make([]bool, cols*rows)
+		for i := range mask {
+			mask[i] = rng.Intn(4) != 0
+		}
+		checkAdjacency(t, cols, rows, mask, adjacencyTable(cols, rows, mask), kingAdjacent)
 	}
 }
 
-func TestGrid(t *testing.T) {
-	gs := GridString("abcdefghi", 3, 3)
-	expect := "+---+---+---+\n" +
-		"| A | B | C |\n" +
-		"+---+---+---+\n" +
-		"| D | E | F |\n" +
-		"+---+---+---+\n" +
-		"| G | H | I |\n" +
-		"+---+---+---+\n"
-	if gs != expect {
-		t.Error("did not get expected grid string")
+func TestAdjacencyTable(t *testing.T) {
+	table := adjacencyTable(4, 4, nil)
+	for sq := range table {
+		if !reflect.DeepEqual(table[sq], calculateAdjacency(4, 4, sq)) {
+			t.Error("wrong adjacency for square", sq)
+		}
+	}
+
+	// Plus-shaped board with corners masked.
+	mask := []bool{
+		false, true, false,
+		true, true, true,
+		false, true, false,
+	}
+	table = adjacencyTable(3, 3, mask)
+	expect := [][]int{nil, {3, 4, 5}, nil, {1, 4, 7}, {1, 3, 5, 7}, {1, 4, 7}, nil, {3, 4, 5}, nil}
+	if !reflect.DeepEqual(table, expect) {
+		t.Fatal("wrong adjacency for masked board:", table)
 	}
 }
 
-func TestSolver(t *testing.T) {
-	s, err := New(4, 5, "")
+func TestAdjacencyGraph(t *testing.T) {
+	s, err := New(4, 4, writeWords(t, "cat"))
 	if err != nil {
 		t.Fatal(err)
 	}
+	graph := s.AdjacencyGraph()
+	expect := map[int][]int{
+		0:  {1, 4, 5},
+		1:  {0, 2, 4, 5, 6},
+		2:  {1, 3, 5, 6, 7},
+		3:  {2, 6, 7},
+		4:  {0, 1, 5, 8, 9},
+		5:  {0, 1, 2, 4, 6, 8, 9, 10},
+		6:  {1, 2, 3, 5, 7, 9, 10, 11},
+		7:  {2, 3, 6, 10, 11},
+		8:  {4, 5, 9, 12, 13},
+		9:  {4, 5, 6, 8, 10, 12, 13, 14},
+		10: {5, 6, 7, 9, 11, 13, 14, 15},
+		11: {6, 7, 10, 14, 15},
+		12: {8, 9, 13},
+		13: {8, 9, 10, 12, 14},
+		14: {9, 10, 11, 13, 15},
+		15: {10, 11, 14},
+	}
+	if !reflect.DeepEqual(graph, expect) {
+		t.Fatal("wrong adjacency graph:", graph)
+	}
 
-	xlen, ylen := s.Dimensions()
-	if xlen != 4 || ylen != 5 {
-		t.Fatal("incorrect board dimensions")
+	// Modifying the graph does not affect the solver.
+	graph[0][0] = 15
+	if s.AdjacencyGraph()[0][0] != 1 {
+		t.Fatal("adjacency graph not a copy")
 	}
 
-	if s.BoardSize() != xlen*ylen {
-		t.Fatal("incorrect board size")
+	mask := []bool{false, true, false, true, true, true, false, true, false}
+	s, err = NewMasked(3, 3, mask, writeWords(t, "cat"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	graph = s.AdjacencyGraph()
+	if len(graph) != 5 {
+		t.Fatal("expected 5 squares in graph, got", len(graph))
 	}
+	if _, ok := graph[0]; ok {
+		t.Fatal("absent square in graph")
+	}
+}
 
-	if s.WordCount() < 1 {
-		t.Fatal("expected more words")
+func TestUniqueSortedWords(t *testing.T) {
+	words := []string{"gamma", "delta", "alpha", "beta", "zeta", "delta", "delta"}
+	usw := uniqueSortedWords(words)
+	if len(usw) != 5 {
+		t.Fatal("wrong number of unique words")
 	}
 
-	grid := "qadfetriihkriflv"
-	words, err := s.Solve(grid)
-	if err == nil {
-		t.Error("failed to catch missing letters")
+	for i, w := range []string{"alpha", "beta", "delta", "gamma", "zeta"} {
+		if w != usw[i] {
+			t.Fatal("words not sorted")
+		}
 	}
+}
 
-	grid = "qadfetriihkriflvqadfetriihkriflv"
-	words, err = s.Solve(grid)
-	if err == nil {
-		t.Error("failed to catch too many letters")
+func TestUniqueWords(t *testing.T) {
+	words := []string{"gamma", "delta", "alpha", "beta", "zeta", "delta", "delta"}
+	uw := uniqueWords(words, nil)
+	for i, w := range []string{"gamma", "delta", "alpha", "beta", "zeta"} {
+		if w != uw[i] {
+			t.Fatal("words not unique in original order")
+		}
+	}
+	if len(uw) != 5 {
+		t.Fatal("wrong number of unique words")
 	}
+}
 
-	grid = "qadfetriihkriflvctor"
-	words, err = s.Solve(grid)
+func TestSolverUnsorted(t *testing.T) {
+	s, err := New(4, 5, "", WithSortResults(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflvctor"
+	words, err := s.Solve(grid)
 	if err != nil {
 		t.Fatal(err)
 	}
-
-	fmt.Printf("Found %d solutions for %dx%d grid:\n", len(words), xlen, ylen)
-	fmt.Println(s.Grid(grid))
 	if len(words) != 80 {
 		t.Fatal("wrong number of solutions")
 	}
-	for _, w := range words {
-		fmt.Print(w, " ")
+
+	s, err = New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sorted, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(uniqueSortedWords(words), sorted) {
+		t.Fatal("unsorted results do not match sorted results")
 	}
-	fmt.Println("")
 }
 
-func genGrid(boardSize int) string {
-	var c rune
-	sbgrid := make([]rune, 0, boardSize)
-	for i := 0; i < boardSize; i++ {
-		if c == 26 {
-			c = 0
+func TestSolverWordCase(t *testing.T) {
+	wordsPath := writeWords(t, "queen", "quit", "tin")
+	grid := "qeentinx"
+	tests := []struct {
+		wordCase WordCase
+		expect   []string
+	}{
+		{LowerCase, []string{"queen", "quit", "tin"}},
+		{UpperCase, []string{"QUEEN", "QUIT", "TIN"}},
+		{TitleCase, []string{"Queen", "Quit", "Tin"}},
+	}
+	for _, tc := range tests {
+		s, err := New(4, 2, wordsPath, WithWordCase(tc.wordCase))
+		if err != nil {
+			t.Fatal(err)
+		}
+		words, err := s.Solve(grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(words, tc.expect) {
+			t.Errorf("expected %v, got %v", tc.expect, words)
 		}
-		sbgrid = append(sbgrid, 'a'+c)
-		c++
 	}
-	return string(sbgrid)
 }
 
-func BenchmarkSolver(b *testing.B) {
-	const xlen = 50
-	const ylen = 50
-	s, _ := New(xlen, ylen, "")
-	grid := genGrid(s.BoardSize())
+func TestSolveWithScratch(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflvctor"
+	expect, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		s.Solve(grid)
+	var sc Scratch
+	for i := 0; i < 3; i++ {
+		words, err := s.SolveWithScratch(grid, &sc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(words, expect) {
+			t.Fatal("wrong solutions using scratch")
+		}
+	}
+
+	s, err = New(4, 5, "", WithSortResults(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		words, err := s.SolveWithScratch(grid, &sc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(uniqueSortedWords(words), expect) {
+			t.Fatal("wrong unsorted solutions using scratch")
+		}
 	}
 }
 
-func BenchmarkSolverPrecomp(b *testing.B) {
-	const xlen = 50
-	const ylen = 50
-	s, _ := New(xlen, ylen, "")
-	grid := genGrid(s.BoardSize())
+func TestSolveProgress(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflvctor"
+	var calls [][2]int
+	words, err := s.SolveProgress(grid, func(done, total int) {
+		calls = append(calls, [2]int{done, total})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != s.BoardSize() {
+		t.Fatal("wrong number of progress calls:", len(calls))
+	}
+	for i, c := range calls {
+		if c != [2]int{i + 1, s.BoardSize()} {
+			t.Fatal("wrong progress:", c)
+		}
+	}
+	expect, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, expect) {
+		t.Fatal("wrong solutions with progress")
+	}
+}
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		s.Solve(grid)
+func TestResultCapacity(t *testing.T) {
+	s, err := New(4, 5, "", WithResultCapacity(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflvctor"
+	var sc Scratch
+	if _, err = s.SolveWithScratch(grid, &sc); err != nil {
+		t.Fatal(err)
+	}
+	if cap(sc.words) != 1000 {
+		t.Fatal("expected result capacity 1000, got", cap(sc.words))
+	}
+
+	s, err = New(4, 5, "", WithResultCapacity(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc = Scratch{}
+	if _, err = s.SolveWithScratch(grid, &sc); err != nil {
+		t.Fatal(err)
+	}
+	if cap(sc.words) != DefaultResultCapacity {
+		t.Fatal("expected default result capacity, got", cap(sc.words))
+	}
+}
+
+func TestWordFilter(t *testing.T) {
+	filter := func(word string) (string, bool) {
+		if len(word) < 4 {
+			return "", false
+		}
+		return strings.ToUpper(word), true
+	}
+	s, err := New(3, 3, writeWords(t, "cat", "cats", "scat", "tea", "seat", "east"), WithWordFilter(filter))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// +---+---+---+
+	// | C | A | T |
+	// +---+---+---+
+	// | X | E | S |
+	// +---+---+---+
+	// | X | X | X |
+	// +---+---+---+
+	grid := "catxesxxx"
+	words, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []string{"CATS", "EAST", "SEAT"}
+	if !reflect.DeepEqual(words, expect) {
+		t.Fatalf("expected %v, got %v", expect, words)
+	}
+	count, err := s.SolveCount(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != len(expect) {
+		t.Fatal("wrong count of filtered words:", count)
+	}
+	word, ok, err := s.FirstWord(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || len(word) < 4 || word != strings.ToUpper(word) {
+		t.Fatal("first word not filtered:", word)
+	}
+	paths, err := s.SolvePaths(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range paths {
+		if len(p.Word) < 4 || p.Word != strings.ToUpper(p.Word) {
+			t.Fatal("path word not filtered:", p.Word)
+		}
+	}
+}
+
+func TestPadding(t *testing.T) {
+	wordsFile := writeWords(t, "cat", "cats", "dog", "sod", "toga")
+	s, err := New(4, 4, wordsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Solve("catsdoga"); !errors.Is(err, ErrGridTooShort) {
+		t.Fatal("expected ErrGridTooShort, got", err)
+	}
+
+	s, err = New(4, 4, wordsFile, WithPadding(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Half-filled board:
+	// +---+---+---+---+
+	// | C | A | T | S |
+	// +---+---+---+---+
+	// | D | O | G | A |
+	// +---+---+---+---+
+	// |   |   |   |   |
+	// +---+---+---+---+
+	// |   |   |   |   |
+	// +---+---+---+---+
+	words, err := s.Solve("CatsDoga")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []string{"cat", "cats", "dog", "toga"}
+	if !reflect.DeepEqual(words, expect) {
+		t.Fatalf("expected %v, got %v", expect, words)
+	}
+
+	if _, err = s.Solve("catsdog."); !errors.Is(err, ErrInvalidCharacter) {
+		t.Fatal("expected ErrInvalidCharacter, got", err)
+	}
+	if _, err = s.Solve(strings.Repeat("a", 17)); !errors.Is(err, ErrGridTooLong) {
+		t.Fatal("expected ErrGridTooLong, got", err)
+	}
+}
+
+func TestSolveDeadline(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflvctor"
+	expect, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	words, complete, err := s.SolveDeadline(grid, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !complete || !reflect.DeepEqual(words, expect) {
+		t.Fatal("expected all solutions")
+	}
+
+	// Every path on a board of all the same letter spells a prefix of the
+	// longest word, so the search takes far longer than the deadline.
+	var eWords []string
+	for i := 3; i <= 16; i++ {
+		eWords = append(eWords, strings.Repeat("e", i))
+	}
+	s, err = New(4, 4, writeWords(t, eWords...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	words, complete, err = s.SolveDeadline(strings.Repeat("e", 16), 50*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if complete {
+		t.Fatal("expected incomplete search")
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatal("search did not stop at deadline")
+	}
+	if len(words) == 0 || !sort.StringsAreSorted(words) {
+		t.Fatal("expected sorted partial results, got", words)
+	}
+
+	if _, _, err = s.SolveDeadline("eee", time.Second); !errors.Is(err, ErrGridTooShort) {
+		t.Fatal("expected ErrGridTooShort, got", err)
+	}
+}
+
+func TestSolverBadNew(t *testing.T) {
+	_, err := New(4, 5, "_not_here_")
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("failed to catch bad file")
+	}
+
+	_, err = New(-4, 5, "")
+	if !errors.Is(err, ErrInvalidDimensions) {
+		t.Fatal("failed to catch negative dimension")
+	}
+
+	_, err = New(4, 0, "")
+	if !errors.Is(err, ErrInvalidDimensions) {
+		t.Fatal("failed to catch zero dimension")
+	}
+}
+
+func TestCanonicalGrid(t *testing.T) {
+	// ABC
+	// DEF
+	// GHI
+	square := []string{
+		"abcdefghi", // identity
+		"gdahebifc", // rotated 90 degrees clockwise
+		"ihgfedcba", // rotated 180 degrees
+		"cfibehadg", // rotated 270 degrees
+		"cbafedihg", // reflected across middle column
+		"ghidefabc", // reflected across middle row
+		"adgbehcfi", // transposed
+		"ifchebgda", // transposed across other diagonal
+	}
+	for _, g := range square {
+		if c := CanonicalGrid(strings.ToUpper(g), 3, 3); c != "abcdefghi" {
+			t.Errorf("wrong canonical grid for %s: %s", g, c)
+		}
+	}
+	if CanonicalGrid("abcdefgih", 3, 3) == "abcdefghi" {
+		t.Error("grids that are not symmetries have same canonical grid")
+	}
+
+	// ABC
+	// DEF
+	rect := []string{"abcdef", "cbafed", "defabc", "fedcba"}
+	for _, g := range rect {
+		if c := CanonicalGrid(g, 3, 2); c != "abcdef" {
+			t.Errorf("wrong canonical grid for %s: %s", g, c)
+		}
+	}
+	// Transposed rectangle has other dimensions, so is not a symmetry.
+	if c := CanonicalGrid("fcebda", 3, 2); c == "abcdef" {
+		t.Error("rotated rectangle used as symmetry")
+	}
+
+	// Equivalent boards have the same solutions.
+	s, err := New(4, 4, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflv"
+	canonical := CanonicalGrid(grid, 4, 4)
+	a, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := s.Solve(canonical)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Fatal("canonical grid has different solutions")
+	}
+}
+
+func TestMaxBoardSize(t *testing.T) {
+	wordsFile := writeWords(t, "cat")
+	_, err := New(100000, 100000, wordsFile)
+	if !errors.Is(err, ErrBoardTooLarge) {
+		t.Fatal("expected ErrBoardTooLarge, got", err)
+	}
+	// Dimensions whose product overflows are rejected.
+	_, err = New(math.MaxInt/2, 3, wordsFile)
+	if !errors.Is(err, ErrBoardTooLarge) {
+		t.Fatal("expected ErrBoardTooLarge, got", err)
+	}
+	if _, err = New(100, 100, wordsFile); err != nil {
+		t.Fatal("board of default maximum size rejected:", err)
+	}
+
+	_, err = New(5, 5, wordsFile, WithMaxBoardSize(24))
+	if !errors.Is(err, ErrBoardTooLarge) {
+		t.Fatal("expected ErrBoardTooLarge, got", err)
+	}
+	if _, err = New(200, 200, wordsFile, WithMaxBoardSize(40000)); err != nil {
+		t.Fatal("board within maximum size rejected:", err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	_, err := New(0, 4, "")
+	if !errors.Is(err, ErrInvalidDimensions) {
+		t.Error("expected ErrInvalidDimensions, got", err)
+	}
+
+	_, err = New(4, 4, "_not_here_")
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Error("expected os.ErrNotExist, got", err)
+	}
+
+	var zero Solver
+	_, err = zero.Solve("")
+	if !errors.Is(err, ErrNoDictionary) {
+		t.Error("expected ErrNoDictionary, got", err)
+	}
+
+	s, err := New(4, 4, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Solve("qadfetri")
+	if !errors.Is(err, ErrGridTooShort) {
+		t.Error("expected ErrGridTooShort, got", err)
+	}
+	_, err = s.Solve("qadfetriihkriflvctor")
+	if !errors.Is(err, ErrGridTooLong) {
+		t.Error("expected ErrGridTooLong, got", err)
+	}
+	if err.Error() != "too many letters for board: have 20, need 16" {
+		t.Error("unexpected error message:", err)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	gs := GridString("abcdefghi", 3, 3)
+	expect := "+---+---+---+\n" +
+		"| A | B | C |\n" +
+		"+---+---+---+\n" +
+		"| D | E | F |\n" +
+		"+---+---+---+\n" +
+		"| G | H | I |\n" +
+		"+---+---+---+\n"
+	if gs != expect {
+		t.Error("did not get expected grid string")
+	}
+}
+
+func TestOrigin(t *testing.T) {
+	wordsFile := writeWords(t, "cat", "act", "tac")
+	top, err := New(3, 2, wordsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bottom, err := New(3, 2, wordsFile, WithOrigin(BottomLeft))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	grid := "catxyz"
+	expect := "+---+---+---+\n" +
+		"| C | A | T |\n" +
+		"+---+---+---+\n" +
+		"| X | Y | Z |\n" +
+		"+---+---+---+\n"
+	if gs := top.Grid(grid); gs != expect {
+		t.Error("wrong top-left grid:\n" + gs)
+	}
+	expect = "+---+---+---+\n" +
+		"| X | Y | Z |\n" +
+		"+---+---+---+\n" +
+		"| C | A | T |\n" +
+		"+---+---+---+\n"
+	if gs := bottom.Grid(grid); gs != expect {
+		t.Error("wrong bottom-left grid:\n" + gs)
+	}
+	if gs := bottom.GridIndexed(grid); !strings.HasPrefix(gs, "+------+------+------+\n| 3 X  | 4 Y  | 5 Z  |") {
+		t.Error("wrong bottom-left indexed grid:\n" + gs)
+	}
+
+	// Squares 0 to 2 are on the first row of the grid string, which is the
+	// top row from the top left and the bottom row from the bottom left.
+	path := []int{0, 3, 4}
+	if dirs := top.DirectionsFor(path); !reflect.DeepEqual(dirs, []string{"S", "E"}) {
+		t.Error("wrong top-left directions:", dirs)
+	}
+	if dirs := bottom.DirectionsFor(path); !reflect.DeepEqual(dirs, []string{"N", "E"}) {
+		t.Error("wrong bottom-left directions:", dirs)
+	}
+
+	// The origin does not change which words are found.
+	topWords, err := top.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bottomWords, err := bottom.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(topWords, bottomWords) || len(topWords) != 2 {
+		t.Error("origin changed words found:", topWords, bottomWords)
+	}
+}
+
+func TestGridIndexed(t *testing.T) {
+	gs := GridStringIndexed("qadfetriihkriflv", 4, 4)
+	expect := "+-------+-------+-------+-------+\n" +
+		"|  0 Qu |  1 A  |  2 D  |  3 F  |\n" +
+		"+-------+-------+-------+-------+\n" +
+		"|  4 E  |  5 T  |  6 R  |  7 I  |\n" +
+		"+-------+-------+-------+-------+\n" +
+		"|  8 I  |  9 H  | 10 K  | 11 R  |\n" +
+		"+-------+-------+-------+-------+\n" +
+		"| 12 I  | 13 F  | 14 L  | 15 V  |\n" +
+		"+-------+-------+-------+-------+\n"
+	if gs != expect {
+		t.Error("did not get expected grid string:\n" + gs)
+	}
+
+	gs = GridStringIndexed(strings.Repeat("a", 110), 11, 10)
+	lines := strings.Split(gs, "\n")
+	if !strings.HasPrefix(lines[1], "|   0 A  |   1 A  |") {
+		t.Error("wrong first row:", lines[1])
+	}
+	if !strings.HasSuffix(lines[19], "| 108 A  | 109 A  |") {
+		t.Error("wrong last row:", lines[19])
+	}
+	for _, line := range lines[:len(lines)-1] {
+		if len(line) != len(lines[0]) {
+			t.Fatal("rows not aligned")
+		}
+	}
+
+	s, err := NewMasked(3, 3, []bool{true, true, true, true, false, true, true, true, true}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(s.GridIndexed("abcdxefgh"), "| 4    |") {
+		t.Error("masked square not blank")
+	}
+}
+
+func TestSolver(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	xlen, ylen := s.Dimensions()
+	if xlen != 4 || ylen != 5 {
+		t.Fatal("incorrect board dimensions")
+	}
+
+	if s.BoardSize() != xlen*ylen {
+		t.Fatal("incorrect board size")
+	}
+
+	if s.WordCount() < 1 {
+		t.Fatal("expected more words")
+	}
+
+	if s.MinWordLength() != DefaultMinWordLength {
+		t.Fatal("wrong minimum word length:", s.MinWordLength())
+	}
+
+	grid := "qadfetriihkriflv"
+	words, err := s.Solve(grid)
+	if !errors.Is(err, ErrGridTooShort) {
+		t.Error("failed to catch missing letters")
+	}
+
+	grid = "qadfetriihkriflvqadfetriihkriflv"
+	words, err = s.Solve(grid)
+	if !errors.Is(err, ErrGridTooLong) {
+		t.Error("failed to catch too many letters")
+	}
+
+	grid = "qadfetriihkriflvct0r"
+	words, err = s.Solve(grid)
+	if !errors.Is(err, ErrInvalidCharacter) {
+		t.Error("failed to catch invalid character")
+	}
+
+	grid = "qadfetriihkriflvctor"
+	words, err = s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fmt.Printf("Found %d solutions for %dx%d grid:\n", len(words), xlen, ylen)
+	fmt.Println(s.Grid(grid))
+	if len(words) != 80 {
+		t.Fatal("wrong number of solutions")
+	}
+	for _, w := range words {
+		fmt.Print(w, " ")
+	}
+	fmt.Println("")
+}
+
+func TestSolverMasked(t *testing.T) {
+	wordsPath := writeWords(t, "act", "axe", "cat", "eat", "sat", "set", "tax", "tea")
+
+	// Plus-shaped board with corners masked.
+	mask := []bool{
+		false, true, false,
+		true, true, true,
+		false, true, false,
+	}
+	grid := "xcxatsxex"
+	s, err := New(3, 3, wordsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	words, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []string{"act", "axe", "cat", "eat", "set", "tax", "tea"}
+	if !reflect.DeepEqual(words, expect) {
+		t.Fatal("wrong solutions for unmasked board:", words)
+	}
+
+	s, err = NewMasked(3, 3, mask, wordsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	words, err = s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect = []string{"act", "cat", "eat", "set", "tea"}
+	if !reflect.DeepEqual(words, expect) {
+		t.Fatal("wrong solutions for masked board:", words)
+	}
+	expectGrid := "+---+---+---+\n" +
+		"|   | C |   |\n" +
+		"+---+---+---+\n" +
+		"| A | T | S |\n" +
+		"+---+---+---+\n" +
+		"|   | E |   |\n" +
+		"+---+---+---+\n"
+	if s.Grid(grid) != expectGrid {
+		t.Error("did not get expected grid string")
+	}
+
+	// Masking the middle column leaves two isolated regions.
+	mask = []bool{
+		true, false, true,
+		true, false, true,
+		true, false, true,
+	}
+	grid = "cea" + "aet" + "tes"
+	s, err = NewMasked(3, 3, mask, wordsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	words, err = s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"cat"}) {
+		t.Fatal("wrong solutions for isolated regions:", words)
+	}
+
+	_, err = NewMasked(3, 3, mask[:8], wordsPath)
+	if !errors.Is(err, ErrInvalidDimensions) {
+		t.Fatal("expected ErrInvalidDimensions, got", err)
+	}
+}
+
+func TestFirstWord(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflvctor"
+	word, ok, err := s.FirstWord(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("expected word")
+	}
+	words, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sortedContains(words, word) {
+		t.Fatal("first word not in solutions:", word)
+	}
+
+	word, ok, err = s.FirstWord("xxxxxxxxxxxxxxxxxxxx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok || word != "" {
+		t.Fatal("expected no word")
+	}
+
+	if _, _, err = s.FirstWord("abc"); !errors.Is(err, ErrGridTooShort) {
+		t.Fatal("expected ErrGridTooShort, got", err)
+	}
+}
+
+func TestSolveCount(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflvctor"
+	n, err := s.SolveCount(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 80 {
+		t.Fatal("wrong number of solutions:", n)
+	}
+
+	words, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byLength := make(map[int]int)
+	for _, w := range words {
+		byLength[len(w)]++
+	}
+	for length := 0; length <= 8; length++ {
+		n, err = s.SolveCountByLength(grid, length)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != byLength[length] {
+			t.Errorf("expected %d words of length %d, got %d", byLength[length], length, n)
+		}
+	}
+
+	// The Qu tile is two letters.
+	s, err = New(2, 2, writeWords(t, "quit", "tiq"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, _ = s.SolveCountByLength("qitx", 4); n != 1 {
+		t.Fatal("expected 1 word of length 4, got", n)
+	}
+	if n, _ = s.SolveCountByLength("qitx", 3); n != 1 {
+		t.Fatal("expected 1 word of length 3, got", n)
+	}
+
+	if _, err = s.SolveCount("qit"); !errors.Is(err, ErrGridTooShort) {
+		t.Fatal("expected ErrGridTooShort, got", err)
+	}
+}
+
+func TestSolveTree(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflvctor"
+	tree, err := s.SolveTree(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	words, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.Len() != len(words) {
+		t.Fatal("wrong number of words in tree")
+	}
+
+	var quWords []string
+	tree.Walk("qua", func(key string, _ any) bool {
+		quWords = append(quWords, key)
+		return false
+	})
+	expect := []string{"qua", "quad", "quark", "quart", "quarte", "quate"}
+	if !reflect.DeepEqual(quWords, expect) {
+		t.Fatal("wrong words with prefix:", quWords)
+	}
+
+	if _, ok := tree.Get("quartz"); ok {
+		t.Fatal("found word not on board")
+	}
+	tree.Delete("quad")
+	if words, _ = s.Solve(grid); !sortedContains(words, "quad") {
+		t.Fatal("modifying tree affected solver")
+	}
+}
+
+func TestNodeLimit(t *testing.T) {
+	// Every path on a board of all the same letter spells a prefix of the
+	// longest word, so the search explores every path on the board.
+	var words []string
+	for i := 3; i <= 16; i++ {
+		words = append(words, strings.Repeat("e", i))
+	}
+	wordsFile := writeWords(t, words...)
+	grid := strings.Repeat("e", 16)
+
+	s, err := New(4, 4, wordsFile, WithNodeLimit(10000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	found, err := s.Solve(grid)
+	if !errors.Is(err, ErrNodeLimit) {
+		t.Fatal("expected ErrNodeLimit, got", err)
+	}
+	if len(found) == 0 || len(found) == len(words) {
+		t.Fatal("expected incomplete results, got", len(found), "words")
+	}
+	if _, err = s.SolveCount(grid); !errors.Is(err, ErrNodeLimit) {
+		t.Fatal("expected ErrNodeLimit from SolveCount, got", err)
+	}
+
+	// A board that does not reach the limit returns all words.
+	s, err = New(4, 5, "", WithNodeLimit(10000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	found, err = s.Solve("qadfetriihkriflvctor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 80 {
+		t.Fatal("wrong number of words:", len(found))
+	}
+}
+
+func TestCheckDictionary(t *testing.T) {
+	s, err := New(4, 4, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := CheckDictionary("", 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Accepted != s.WordCount() {
+		t.Fatalf("accepted %d words, dictionary has %d", report.Accepted, s.WordCount())
+	}
+	var rejected int
+	for _, n := range report.Rejected {
+		rejected += n
+	}
+	if report.Scanned != report.Accepted+rejected {
+		t.Fatalf("wrong counts: %+v", report)
+	}
+
+	report, err = CheckDictionary(writeWords(t, "cat", "at", "Bob", "", "qat", "dog"), 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := DictionaryReport{
+		Scanned:  5,
+		Accepted: 2,
+		Rejected: map[string]int{
+			ReasonTooShort:    1,
+			ReasonCapitalized: 1,
+			ReasonQWithoutU:   1,
+		},
+	}
+	if !reflect.DeepEqual(report, expect) {
+		t.Fatalf("expected %+v, got %+v", expect, report)
+	}
+
+	_, err = CheckDictionary(writeWords(t, "at", "be"), 4, 4)
+	if !errors.Is(err, ErrEmptyDictionary) {
+		t.Fatal("expected ErrEmptyDictionary, got", err)
+	}
+
+	corrupt := filepath.Join(t.TempDir(), "words.txt.gz")
+	if err = os.WriteFile(corrupt, []byte("not gzip data"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = CheckDictionary(corrupt, 4, 4); err == nil {
+		t.Fatal("expected error reading corrupt file")
+	}
+	if _, err = CheckDictionary("_not_here_", 4, 4); !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("expected os.ErrNotExist, got", err)
+	}
+	if _, err = CheckDictionary("", 0, 4); !errors.Is(err, ErrInvalidDimensions) {
+		t.Fatal("expected ErrInvalidDimensions, got", err)
+	}
+	if _, err = CheckDictionary("", 4, 4, WithMaxLength(2)); !errors.Is(err, ErrInvalidWordLength) {
+		t.Fatal("expected ErrInvalidWordLength, got", err)
+	}
+	if _, err = CheckDictionary("", 2, 2, WithMinLength(5)); !errors.Is(err, ErrInvalidWordLength) {
+		t.Fatal("expected ErrInvalidWordLength, got", err)
+	}
+}
+
+func TestCaseSensitive(t *testing.T) {
+	wordsPath := writeWords(t, "Nice", "ice", "Quito", "quit", "Bob")
+	s, err := New(2, 3, wordsPath, WithCaseSensitive(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.WordCount() != 5 {
+		t.Fatal("expected capitalized words to be kept, word count:", s.WordCount())
+	}
+	// +---+---+
+	// | N | i |
+	// +---+---+
+	// | c | e |
+	// +---+---+
+	// | x | x |
+	// +---+---+
+	for grid, expect := range map[string][]string{
+		"Nicexx": {"Nice", "ice"},
+		"nicexx": {"ice"},
+		"NICExx": nil,
+		"Qitoxx": {"Quito"},
+		"qiotxx": {"quit"},
+	} {
+		words, err := s.Solve(grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(words) != len(expect) || (len(expect) != 0 && !reflect.DeepEqual(words, expect)) {
+			t.Errorf("expected %v for grid %q, got %v", expect, grid, words)
+		}
+	}
+	path, ok, err := s.FindPath("Nicexx", "Nice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || !reflect.DeepEqual(path, []int{0, 1, 2, 3}) {
+		t.Fatal("wrong path for Nice:", path)
+	}
+	if _, err = s.Solve("Nic3xx"); !errors.Is(err, ErrInvalidCharacter) {
+		t.Fatal("expected ErrInvalidCharacter, got", err)
+	}
+
+	// By default, capitalized words are rejected and the grid is lower cased.
+	s, err = New(2, 3, wordsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.WordCount() != 2 {
+		t.Fatal("expected capitalized words to be rejected, word count:", s.WordCount())
+	}
+	words, err := s.Solve("NICExx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"ice"}) {
+		t.Fatal("expected ice, got", words)
+	}
+}
+
+func TestWithMinLength(t *testing.T) {
+	wordsPath := writeWords(t, "q", "at", "qua", "ta", "cat", "tact", "act")
+	// +---+---+
+	// | C | A |
+	// +---+---+
+	// | T | X |
+	// +---+---+
+	for minLen, expect := range map[int][]string{
+		1: {"act", "at", "cat", "ta"},
+		2: {"act", "at", "cat", "ta"},
+		3: {"act", "cat"},
+		4: nil,
+	} {
+		s, err := New(2, 2, wordsPath, WithMinLength(minLen))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.MinWordLength() != minLen {
+			t.Fatal("wrong minimum word length:", s.MinWordLength())
+		}
+		words, err := s.Solve("catx")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(words) != len(expect) || (len(expect) != 0 && !reflect.DeepEqual(words, expect)) {
+			t.Errorf("expected %v for minimum length %d, got %v", expect, minLen, words)
+		}
+	}
+
+	// A lone "q" is rejected instead of causing a panic.
+	var reasons []string
+	_, err := New(2, 2, wordsPath, WithMinLength(1), WithAudit(func(word string, accepted bool, reason string) {
+		if word == "q" {
+			reasons = append(reasons, reason)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(reasons, []string{ReasonQWithoutU}) {
+		t.Fatal("wrong reason for q:", reasons)
+	}
+
+	for _, minLen := range []int{0, -1, 5} {
+		if _, err = New(2, 2, wordsPath, WithMinLength(minLen)); !errors.Is(err, ErrInvalidWordLength) {
+			t.Errorf("expected ErrInvalidWordLength for minimum length %d, got %v", minLen, err)
+		}
+	}
+}
+
+func TestWithMaxLength(t *testing.T) {
+	s, err := New(50, 50, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.MaxWordLength() != 2500 {
+		t.Fatal("wrong maximum word length:", s.MaxWordLength())
+	}
+	capped, err := New(50, 50, "", WithMaxLength(6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if capped.MaxWordLength() != 6 {
+		t.Fatal("wrong maximum word length:", capped.MaxWordLength())
+	}
+	if capped.WordCount() >= s.WordCount() {
+		t.Fatal("expected fewer words with maximum length")
+	}
+
+	grid := genGrid(s.BoardSize())
+	all, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	words, err := capped.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := filterWords(all, func(w string) bool { return len(w) <= 6 })
+	if !reflect.DeepEqual(words, expect) {
+		t.Fatal("expected only the words of at most 6 letters")
+	}
+
+	// The maximum is clamped to the board size.
+	s, err = New(2, 2, writeWords(t, "cat", "act"), WithMaxLength(20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.MaxWordLength() != 4 {
+		t.Fatal("wrong maximum word length:", s.MaxWordLength())
+	}
+
+	if _, err = New(4, 4, "", WithMaxLength(2)); !errors.Is(err, ErrInvalidWordLength) {
+		t.Fatal("expected ErrInvalidWordLength, got", err)
+	}
+	if _, err = New(4, 4, "", WithMinLength(2), WithMaxLength(2)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func genGrid(boardSize int) string {
+	var c rune
+	sbgrid := make([]rune, 0, boardSize)
+	for i := 0; i < boardSize; i++ {
+		if c == 26 {
+			c = 0
+		}
+		sbgrid = append(sbgrid, 'a'+c)
+		c++
+	}
+	return string(sbgrid)
+}
+
+func BenchmarkSolver(b *testing.B) {
+	const xlen = 50
+	const ylen = 50
+	s, _ := New(xlen, ylen, "")
+	grid := genGrid(s.BoardSize())
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Solve(grid)
+	}
+}
+
+func BenchmarkSolverPrecomp(b *testing.B) {
+	const xlen = 50
+	const ylen = 50
+	s, _ := New(xlen, ylen, "")
+	grid := genGrid(s.BoardSize())
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Solve(grid)
+	}
+}
+
+func BenchmarkSolveWithScratch(b *testing.B) {
+	const xlen = 50
+	const ylen = 50
+	s, _ := New(xlen, ylen, "")
+	grid := genGrid(s.BoardSize())
+	var sc Scratch
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.SolveWithScratch(grid, &sc)
+	}
+}
+
+func BenchmarkSolverUnsorted(b *testing.B) {
+	for _, sorted := range []bool{true, false} {
+		b.Run(fmt.Sprint("sorted=", sorted), func(b *testing.B) {
+			const xlen = 50
+			const ylen = 50
+			s, _ := New(xlen, ylen, "", WithSortResults(sorted))
+			grid := genGrid(s.BoardSize())
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				s.Solve(grid)
+			}
+		})
+	}
+}
+
+func BenchmarkResultCapacity(b *testing.B) {
+	const xlen = 50
+	const ylen = 50
+	s, _ := New(xlen, ylen, "")
+	// A random board this size has many more than DefaultResultCapacity words.
+	rng := rand.New(rand.NewSource(1))
+	grid, _ := FillGrid(strings.Repeat(string(FillPlaceholder), s.BoardSize()), s.BoardSize(), rng)
+	var sc Scratch
+	s.SolveWithScratch(grid, &sc)
+	for _, n := range []int{DefaultResultCapacity, len(sc.words)} {
+		b.Run(fmt.Sprint("capacity=", n), func(b *testing.B) {
+			s, _ := New(xlen, ylen, "", WithResultCapacity(n))
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				s.Solve(grid)
+			}
+		})
+	}
+}
+
+func BenchmarkSolveDenseUnique(b *testing.B) {
+	const xlen = 50
+	const ylen = 50
+	// Letters weighted by English frequency give a dense board, where many
+	// words are found more than once.
+	rng := rand.New(rand.NewSource(1))
+	grid, _ := FillGrid(strings.Repeat(string(FillPlaceholder), xlen*ylen), xlen*ylen, rng)
+	for _, sorted := range []bool{true, false} {
+		b.Run(fmt.Sprint("sorted=", sorted), func(b *testing.B) {
+			s, _ := New(xlen, ylen, "", WithSortResults(sorted))
+			var sc Scratch
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				s.SolveWithScratch(grid, &sc)
+			}
+		})
+	}
+}
+
+// writeWords writes the given words to a temporary words file and returns the
+// path to the file.
+func writeWords(t *testing.T, words ...string) string {
+	wordsPath := filepath.Join(t.TempDir(), "words.txt")
+	err := os.WriteFile(wordsPath, []byte(strings.Join(words, "\n")+"\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
 	}
+	return wordsPath
 }
@@ -0,0 +1,58 @@
+package solver
+
+// SolveSorted finds all solutions for the given Boggle grid, and calls yield
+// with each word in sorted order. If yield returns false, the search stops.
+//
+// Words are emitted while the search is in progress, which lets a UI show
+// words appearing in sorted order as they are discovered. Since every word
+// found by searching from a square begins with that square's letter, the
+// starting squares are searched in alphabetical order of their letters. After
+// all squares with the same letter are searched, the words found from them
+// are sorted and emitted before searching continues with the next letter.
+//
+// This trades some latency for sorted output: the first word is not emitted
+// until all squares with the lowest letter have been searched, and only the
+// words for one letter are buffered at a time. Total throughput is about the
+// same as Solve, which emits nothing until the entire board is searched.
+func (s Solver) SolveSorted(grid string, yield func(word string) bool) error {
+	board, err := s.board(grid)
+	if err != nil {
+		return err
+	}
+
+	// Squares are grouped by letter in byte order, so that upper case
+	// letters, from a case sensitive Solver, come first as they do in sorted
+	// words.
+	var squares [256][]int
+	for sq := 0; sq < len(board); sq++ {
+		if c := board[sq]; isLetter(c) {
+			squares[c] = append(squares[c], sq)
+		}
+	}
+
+	var words []string
+	found := func(word string, _ []int) bool {
+		if out, ok := s.output(word); ok {
+			words = append(words, out)
+		}
+		return true
+	}
+	sc := &Scratch{}
+	for _, initSqs := range squares {
+		words = words[:0]
+		for _, initSq := range initSqs {
+			if !s.search(sc, board, initSq, found) {
+				break
+			}
+		}
+		for _, w := range uniqueSortedWords(words) {
+			if !yield(w) {
+				return nil
+			}
+		}
+		if sc.limited {
+			return ErrNodeLimit
+		}
+	}
+	return nil
+}
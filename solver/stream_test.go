@@ -0,0 +1,47 @@
+package solver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSolveSorted(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflvctor"
+	expect, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var words []string
+	err = s.SolveSorted(grid, func(word string) bool {
+		words = append(words, word)
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, expect) {
+		t.Fatal("streamed words do not match sorted solutions")
+	}
+
+	// Stop after 5 words.
+	words = words[:0]
+	err = s.SolveSorted(grid, func(word string) bool {
+		words = append(words, word)
+		return len(words) < 5
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, expect[:5]) {
+		t.Fatal("wrong words streamed before stopping")
+	}
+
+	if err = s.SolveSorted("abc", func(string) bool { return true }); err == nil {
+		t.Fatal("failed to catch missing letters")
+	}
+}
@@ -0,0 +1,145 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/gammazero/radixtree"
+)
+
+// ErrReadOnlyDictionary is returned when changing the dictionary of a Solver
+// whose dictionary backend does not support changes.
+var ErrReadOnlyDictionary = errors.New("dictionary cannot be changed")
+
+// WordUpdate is a change to the dictionary of a Solver, applied by
+// ApplyUpdates.
+type WordUpdate struct {
+	// Word is the word to add or remove.
+	Word string
+	// Remove is true to remove the word, and false to add it.
+	Remove bool
+}
+
+// AddWord adds a word to the Solver's dictionary, and returns true if the
+// word was not already in the dictionary. The word is filtered the same as the
+// words in the words file, and an error is returned if the word is rejected.
+//
+// The dictionary is shared by all copies of the Solver. It is safe to change
+// the dictionary while other goroutines are solving grids. A solve that runs
+// while the dictionary changes may find words using the dictionary from
+// before or after the change.
+func (s Solver) AddWord(word string) (bool, error) {
+	key, reason := filterWord(word, s.maxLen, s.minLen, s.cfg.caseSensitive)
+	if reason != "" {
+		return false, fmt.Errorf("cannot add word %q: %s", word, reason)
+	}
+	rd, ok := s.dict.(radixDict)
+	if !ok {
+		return false, ErrReadOnlyDictionary
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rev != nil {
+		s.rev.(radixDict).Put(reverseKey(key), word)
+	}
+	return rd.Put(key, word), nil
+}
+
+// RemoveWord removes a word from the Solver's dictionary, and returns true if
+// the word was in the dictionary. The word is matched without regard to case,
+// the same as words in a deny file. See AddWord for how changes affect
+// concurrent solves.
+func (s Solver) RemoveWord(word string) (bool, error) {
+	rd, ok := s.dict.(radixDict)
+	if !ok {
+		return false, ErrReadOnlyDictionary
+	}
+	key := normalizeWord(word, s.cfg.caseSensitive)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rev != nil {
+		s.rev.(radixDict).Delete(reverseKey(key))
+	}
+	return rd.Delete(key), nil
+}
+
+// ReloadWords replaces all the words in the Solver's dictionary with the words
+// read from the given words file, the same as if the Solver were created again
+// with the file. The options that the Solver was created with, such as a deny
+// file, apply to the new words. If the file cannot be loaded, then an error is
+// returned and the dictionary is not changed.
+//
+// The new words are loaded before the dictionary is locked, so solves are
+// only blocked while the loaded words are swapped in. See AddWord for how
+// changes affect concurrent solves.
+func (s Solver) ReloadWords(wordsPath string) error {
+	rd, ok := s.dict.(radixDict)
+	if !ok {
+		return ErrReadOnlyDictionary
+	}
+	var freq map[string]int
+	if s.freq != nil {
+		freq = make(map[string]int)
+	}
+	rt, err := loadWords(wordsPath, s.maxLen, s.minLen, s.cfg, freq)
+	if err != nil {
+		return err
+	}
+	var rev *radixtree.Tree
+	if s.rev != nil {
+		rev = reverseTree(rt)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Replace the contents of the trees, which are shared by all copies of
+	// the Solver.
+	*rd.Tree = *rt
+	if rev != nil {
+		*s.rev.(radixDict).Tree = *rev
+	}
+	if freq != nil {
+		clear(s.freq)
+		maps.Copy(s.freq, freq)
+	}
+	return nil
+}
+
+// ApplyUpdates starts a goroutine that applies each update received from the
+// updates channel to the Solver's dictionary, using AddWord or RemoveWord. If
+// applying an update fails, then onError, if not nil, is called with the
+// update and the error, and the goroutine continues with the next update.
+//
+// The goroutine exits when the updates channel is closed or the context is
+// canceled. Updates that are still in the channel when the context is canceled
+// are not applied. The returned channel is closed when the goroutine exits, so
+// that callers can wait for all updates to be applied after closing the
+// updates channel.
+func (s Solver) ApplyUpdates(ctx context.Context, updates <-chan WordUpdate, onError func(WordUpdate, error)) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case u, ok := <-updates:
+				if !ok {
+					return
+				}
+				var err error
+				if u.Remove {
+					_, err = s.RemoveWord(u.Word)
+				} else {
+					_, err = s.AddWord(u.Word)
+				}
+				if err != nil && onError != nil {
+					onError(u, err)
+				}
+			}
+		}
+	}()
+	return done
+}
@@ -0,0 +1,219 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestAddRemoveWord(t *testing.T) {
+	s, err := New(2, 2, writeWords(t, "cat"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	added, err := s.AddWord("act")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !added {
+		t.Fatal("expected word to be added")
+	}
+	if added, _ = s.AddWord("cat"); added {
+		t.Fatal("word already in dictionary was added")
+	}
+	if _, err = s.AddWord("at"); err == nil {
+		t.Fatal("expected error adding word that is too short")
+	}
+	if _, err = s.AddWord("quest"); err == nil {
+		t.Fatal("expected error adding word that is too long")
+	}
+
+	// Copies of the Solver share the dictionary.
+	cp := s
+	words, err := cp.Solve("catx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"act", "cat"}) {
+		t.Fatal("wrong words:", words)
+	}
+
+	removed, err := s.RemoveWord("CAT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !removed {
+		t.Fatal("expected word to be removed")
+	}
+	if removed, _ = s.RemoveWord("dog"); removed {
+		t.Fatal("removed word not in dictionary")
+	}
+	if words, _ = s.Solve("catx"); !reflect.DeepEqual(words, []string{"act"}) {
+		t.Fatal("wrong words:", words)
+	}
+	if s.WordCount() != 1 {
+		t.Fatal("wrong word count:", s.WordCount())
+	}
+
+	s, err = New(2, 2, writeWords(t, "cat"), WithBackend(CompactTrie))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.AddWord("act"); !errors.Is(err, ErrReadOnlyDictionary) {
+		t.Fatal("expected ErrReadOnlyDictionary, got", err)
+	}
+	if _, err = s.RemoveWord("cat"); !errors.Is(err, ErrReadOnlyDictionary) {
+		t.Fatal("expected ErrReadOnlyDictionary, got", err)
+	}
+}
+
+func TestApplyUpdates(t *testing.T) {
+	s, err := New(4, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflvctor"
+
+	updates := make(chan WordUpdate)
+	var errCount int
+	done := s.ApplyUpdates(context.Background(), updates, func(u WordUpdate, err error) {
+		errCount++
+	})
+
+	// Solve concurrently with updates.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				if _, err := s.Solve(grid); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 20; i++ {
+		updates <- WordUpdate{Word: "quarte", Remove: i%2 == 0}
+	}
+	updates <- WordUpdate{Word: "xi"}
+	updates <- WordUpdate{Word: "kirt"}
+	updates <- WordUpdate{Word: "rad", Remove: true}
+	close(updates)
+	<-done
+	wg.Wait()
+
+	if errCount != 1 {
+		t.Fatal("expected 1 error, got", errCount)
+	}
+	words, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sortedContains(words, "quarte") || !sortedContains(words, "kirt") || sortedContains(words, "rad") {
+		t.Fatal("updates not applied")
+	}
+
+	// Updates are not applied after the context is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	updates = make(chan WordUpdate, 1)
+	done = s.ApplyUpdates(ctx, updates, nil)
+	cancel()
+	<-done
+	updates <- WordUpdate{Word: "quarte", Remove: true}
+	if words, _ = s.Solve(grid); !sortedContains(words, "quarte") {
+		t.Fatal("update applied after cancel")
+	}
+}
+
+func TestReloadWords(t *testing.T) {
+	s, err := New(2, 2, writeWords(t, "cat", "act"), WithReverseIndex(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp := s
+	if err = s.ReloadWords(writeWords(t, "tax", "tac")); err != nil {
+		t.Fatal(err)
+	}
+	words, err := cp.Solve("catx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"tac", "tax"}) {
+		t.Fatal("wrong words after reload:", words)
+	}
+	words, err = cp.SolveEndingAt("catx", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"tax"}) {
+		t.Fatal("reverse index not reloaded:", words)
+	}
+
+	// A failed reload leaves the dictionary unchanged.
+	if err = s.ReloadWords("_not_here_"); err == nil {
+		t.Fatal("expected error reloading missing file")
+	}
+	if err = s.ReloadWords(writeWords(t, "ab")); !errors.Is(err, ErrEmptyDictionary) {
+		t.Fatal("expected ErrEmptyDictionary, got", err)
+	}
+	if s.WordCount() != 2 {
+		t.Fatal("dictionary changed by failed reload")
+	}
+
+	s, err = New(2, 2, writeWords(t, "cat"), WithBackend(CompactTrie))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.ReloadWords(writeWords(t, "act")); !errors.Is(err, ErrReadOnlyDictionary) {
+		t.Fatal("expected ErrReadOnlyDictionary, got", err)
+	}
+}
+
+// TestConcurrentChanges is most useful when run with the race detector.
+func TestConcurrentChanges(t *testing.T) {
+	s, err := New(4, 5, "", WithFrequencyColumn(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := "qadfetriihkriflvctor"
+	smallWords := writeWords(t, "quad", "tear", "dart")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if _, err := s.Solve(grid); err != nil {
+					t.Error(err)
+					return
+				}
+				s.Frequency("quad")
+			}
+		}()
+	}
+	for _, w := range []string{"adfe", "trih", "krif"} {
+		if _, err = s.AddWord(w); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s.RemoveWord(w); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = s.ReloadWords(smallWords); err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+
+	words, err := s.Solve(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"dart", "quad", "tear"}) {
+		t.Fatal("wrong words after changes:", words)
+	}
+}